@@ -0,0 +1,32 @@
+package zincmetric
+
+import "errors"
+
+// AdjacencyMatrix groups documents into a bucket per named filter query and per pair of filters
+// matched together, keyed "a&b". Results decode into AdjacencyMatrixResult.
+func (AggregationBuilder) AdjacencyMatrix(name string, filters map[string]Query) Aggregation {
+	if len(filters) == 0 {
+		return aggregation{name: name, err: errors.New("adjacency matrix aggregation has no filters")}
+	}
+
+	return aggregation{name: name, typ: "adjacency_matrix", body: map[string]any{"filters": namedQueriesJSON(filters)}}
+}
+
+// AdjacencyMatrixResult is the result of AdjacencyMatrix aggregation, buckets with no documents are omitted.
+type AdjacencyMatrixResult struct {
+	Buckets []AdjacencyBucket `json:"buckets"`
+}
+
+// AdjacencyBucket holds documents matching a filter, or both filters of the "a&b" Key.
+type AdjacencyBucket struct {
+	Key      string
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (b *AdjacencyBucket) UnmarshalJSON(data []byte) error {
+	var err error
+	b.Aggregations, err = splitBucket(data, map[string]any{"key": &b.Key, "doc_count": &b.DocCount})
+	return err
+}
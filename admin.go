@@ -0,0 +1,67 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// AdminClient groups index management operations, so setup code can be
+// given index management access separately from the data-plane Client.
+type AdminClient struct {
+	c *Client
+}
+
+// Admin returns index management API of the client.
+func (c *Client) Admin() *AdminClient {
+	return &AdminClient{c: c}
+}
+
+// CreateIndex creates a new index, see Client.CreateIndex.
+func (a *AdminClient) CreateIndex(ctx context.Context, name string, mapping json.RawMessage) error {
+	return a.c.CreateIndex(ctx, name, mapping)
+}
+
+// DeleteIndex deletes the index, see Client.DeleteIndex.
+func (a *AdminClient) DeleteIndex(ctx context.Context, name string) error {
+	return a.c.DeleteIndex(ctx, name)
+}
+
+// SetMapping sets client's index mapping, see Client.SetMapping.
+func (a *AdminClient) SetMapping(ctx context.Context, mapping json.RawMessage) error {
+	return a.c.SetMapping(ctx, mapping)
+}
+
+// GetMapping returns client's index mapping, see Client.GetMapping.
+func (a *AdminClient) GetMapping(ctx context.Context) (json.RawMessage, error) {
+	return a.c.GetMapping(ctx)
+}
+
+// ListIndexes returns names of all indexes, see Client.ListIndexes.
+func (a *AdminClient) ListIndexes(ctx context.Context) ([]string, error) {
+	return a.c.ListIndexes(ctx)
+}
+
+// IndexStats returns client's index statistics, see Client.IndexStats.
+func (a *AdminClient) IndexStats(ctx context.Context) (*IndexStats, error) {
+	return a.c.IndexStats(ctx)
+}
+
+// Rollover rolls client's index alias over to a new index, see Client.Rollover.
+func (a *AdminClient) Rollover(ctx context.Context, conditions RolloverConditions) (bool, string, error) {
+	return a.c.Rollover(ctx, conditions)
+}
+
+// Refresh makes written documents searchable, see Client.Refresh.
+func (a *AdminClient) Refresh(ctx context.Context) error {
+	return a.c.Refresh(ctx)
+}
+
+// WaitForIndexGreen waits for client's index to become healthy, see Client.WaitForIndexGreen.
+func (a *AdminClient) WaitForIndexGreen(ctx context.Context) error {
+	return a.c.WaitForIndexGreen(ctx)
+}
+
+// Migrate moves client's index to a new mapping, see Client.Migrate.
+func (a *AdminClient) Migrate(ctx context.Context, newMapping json.RawMessage, batchSize int) error {
+	return a.c.Migrate(ctx, newMapping, batchSize)
+}
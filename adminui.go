@@ -0,0 +1,132 @@
+package zincmetric
+
+import (
+	"embed"
+	"html/template"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// adminSamples is the number of buffer depth samples, taken every second, shown by AdminHandler.
+const adminSamples = 60
+
+var (
+	//go:embed admin.html
+	adminFS       embed.FS
+	adminTemplate = template.Must(template.ParseFS(adminFS, "admin.html"))
+)
+
+// depthRing holds the latest buffer depth samples.
+type depthRing struct {
+	mu      sync.Mutex
+	samples [adminSamples]int64
+	next    int
+	n       int
+}
+
+func (r *depthRing) add(v int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples[r.next] = v
+	r.next = (r.next + 1) % adminSamples
+	r.n = min(r.n+1, adminSamples)
+}
+
+// values returns samples, oldest first.
+func (r *depthRing) values() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]int64, 0, r.n)
+	for i := range r.n {
+		out = append(out, r.samples[(r.next-r.n+i+adminSamples)%adminSamples])
+	}
+
+	return out
+}
+
+// adminBar is a buffer depth chart bar.
+type adminBar struct {
+	Height float64 // percent of the chart height
+	Value  int64
+}
+
+// adminPage is data of the admin dashboard.
+type adminPage struct {
+	Index         string
+	HealthError   string
+	Stats         Stats
+	FlushInterval time.Duration
+	LastFlush     time.Time
+	Depth         []adminBar
+	MaxDepth      int64
+	Message       string
+}
+
+// AdminHandler returns HTTP handler serving client dashboard with health, statistics and buffer depth
+// of the last minute, allowing buffered documents to be flushed. It should be mounted at a path ending
+// with slash, e.g. http.Handle("/zinc/", http.StripPrefix("/zinc", c.AdminHandler())).
+func (c *Client) AdminHandler() http.Handler {
+	ring := &depthRing{}
+	go func() {
+		tick := time.NewTicker(time.Second)
+		defer tick.Stop()
+
+		for {
+			select {
+			case <-c.closeCh:
+				return
+			case <-tick.C:
+				ring.add(c.bufferDepth.Load())
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		page := adminPage{
+			Index:         c.currentIndex(),
+			Stats:         c.Stats(),
+			FlushInterval: c.flushInterval,
+			Message:       r.URL.Query().Get("message"),
+		}
+		if err := c.ping(); err != nil {
+			page.HealthError = err.Error()
+		}
+		if ns := c.lastFlush.Load(); ns != 0 {
+			page.LastFlush = time.Unix(0, ns)
+		}
+
+		depths := ring.values()
+		for _, d := range depths {
+			page.MaxDepth = max(page.MaxDepth, d)
+		}
+		for _, d := range depths {
+			bar := adminBar{Value: d}
+			if page.MaxDepth > 0 {
+				bar.Height = 100 * float64(d) / float64(page.MaxDepth)
+			}
+			page.Depth = append(page.Depth, bar)
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := adminTemplate.Execute(w, page); err != nil {
+			c.logger.Error("failed to render admin page", logKeyError, err)
+		}
+	})
+	mux.HandleFunc("POST /flush", func(w http.ResponseWriter, _ *http.Request) {
+		message := "Flushed buffered documents."
+		if err := c.Flush(); err != nil {
+			message = "Flush failed: " + err.Error()
+		}
+
+		// Location is kept relative so that the dashboard works behind http.StripPrefix.
+		w.Header().Set("Location", "./?"+url.Values{"message": {message}}.Encode())
+		w.WriteHeader(http.StatusSeeOther)
+	})
+
+	return mux
+}
@@ -0,0 +1,242 @@
+package zincmetric
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Aggregation is a search aggregation built by AggregationBuilder.
+type Aggregation interface {
+	// Name is the key aggregation results are returned under.
+	Name() string
+	// Sub returns copy of the aggregation running aggs on each of its buckets.
+	Sub(aggs ...Aggregation) Aggregation
+	Build() (json.RawMessage, error)
+}
+
+// AggregationBuilder builds search aggregations, e.g.
+//
+//	var ab AggregationBuilder
+//	agg := ab.Terms("hosts", "host", 10).Sub(ab.DateHistogram("per_hour", "@timestamp", "1h"))
+type AggregationBuilder struct{}
+
+// SearchAggregations sets search request aggregations, results can be read using SearchResult.DecodeAggregation.
+func SearchAggregations(aggs ...Aggregation) SearchOption {
+	return func(r *searchRequest) error {
+		b, err := buildAggregations(aggs)
+		if err != nil {
+			return err
+		}
+
+		r.body["aggs"] = b
+		return nil
+	}
+}
+
+// buildAggregations builds aggregations keyed by their names.
+func buildAggregations(aggs []Aggregation) (json.RawMessage, error) {
+	built := make(map[string]json.RawMessage, len(aggs))
+	for _, a := range aggs {
+		b, err := a.Build()
+		if err != nil {
+			return nil, fmt.Errorf("aggregation %q: %w", a.Name(), err)
+		}
+		built[a.Name()] = b
+	}
+
+	return json.Marshal(built)
+}
+
+// aggregation is an aggregation of a single type, e.g. {"terms": {...}, "aggs": {...}}.
+type aggregation struct {
+	name string
+	typ  string
+	body any
+	subs []Aggregation
+	err  error // reported by Build, so builder methods stay chainable
+}
+
+func (a aggregation) Name() string {
+	return a.name
+}
+
+func (a aggregation) Sub(aggs ...Aggregation) Aggregation {
+	a.subs = append(slices.Clip(a.subs), aggs...)
+	return a
+}
+
+func (a aggregation) Build() (json.RawMessage, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	agg := map[string]any{a.typ: a.body}
+	if len(a.subs) > 0 {
+		subs, err := buildAggregations(a.subs)
+		if err != nil {
+			return nil, err
+		}
+		agg["aggs"] = subs
+	}
+
+	return json.Marshal(agg)
+}
+
+// Terms groups documents into buckets of up to size most frequent field values.
+func (AggregationBuilder) Terms(name, field string, size int) Aggregation {
+	body := map[string]any{"field": field}
+	if size > 0 {
+		body["size"] = size
+	}
+
+	return aggregation{name: name, typ: "terms", body: body}
+}
+
+// NestedTerms groups documents by terms of every field, each field being a Terms sub aggregation
+// of the previous one, up to size terms per level. Every aggregation is named by its field,
+// results decode into BucketsResult having the next level in bucket Aggregations.
+func (ab AggregationBuilder) NestedTerms(fields []string, size int) Aggregation {
+	if len(fields) == 0 {
+		return aggregation{err: errors.New("nested terms aggregation has no fields")}
+	}
+
+	agg := ab.Terms(fields[len(fields)-1], fields[len(fields)-1], size)
+	for i := len(fields) - 2; i >= 0; i-- {
+		agg = ab.Terms(fields[i], fields[i], size).Sub(agg)
+	}
+
+	return agg
+}
+
+// DateHistogram groups documents into buckets of date field by interval,
+// either fixed (e.g. "5m", "1h") or calendar aware ("1M", "1q", "1y").
+func (AggregationBuilder) DateHistogram(name, field, interval string) Aggregation {
+	return aggregation{name: name, typ: "date_histogram", body: dateInterval(field, interval)}
+}
+
+// Histogram groups documents into buckets of numeric field values by interval, buckets with less
+// than minDocCount documents are skipped. Non-zero missing puts documents without field into bucket
+// of that value. Results decode into BucketsResult.
+func (AggregationBuilder) Histogram(name, field string, interval float64, minDocCount int, missing float64) Aggregation {
+	if interval <= 0 {
+		return aggregation{name: name, err: fmt.Errorf("invalid histogram interval %v", interval)}
+	}
+
+	body := map[string]any{"field": field, "interval": interval, "min_doc_count": minDocCount}
+	if missing != 0 {
+		body["missing"] = missing
+	}
+
+	return aggregation{name: name, typ: "histogram", body: body}
+}
+
+// AutoDateHistogram groups documents into about buckets buckets of date field, choosing the interval
+// automatically. TimeZone (e.g. "Europe/Vilnius") aligns buckets to local days, empty means UTC.
+// Results decode into BucketsResult.
+func (AggregationBuilder) AutoDateHistogram(name, field string, buckets int, timeZone string) Aggregation {
+	body := map[string]any{"field": field}
+	if buckets > 0 {
+		body["buckets"] = buckets
+	}
+	if timeZone != "" {
+		body["time_zone"] = timeZone
+	}
+
+	return aggregation{name: name, typ: "auto_date_histogram", body: body}
+}
+
+// dateInterval returns date histogram body with interval set as fixed or calendar interval.
+func dateInterval(field, interval string) map[string]any {
+	if calendarIntervalRe.MatchString(interval) {
+		return map[string]any{"field": field, "calendar_interval": interval}
+	}
+
+	return map[string]any{"field": field, "fixed_interval": interval}
+}
+
+// AggregationResults holds raw aggregation results keyed by aggregation name.
+type AggregationResults map[string]json.RawMessage
+
+// Decode unmarshals result of the named aggregation into v, e.g. *CompositeAggResult.
+// Error matching ErrNotFound is returned if there's no such result.
+func (r AggregationResults) Decode(name string, v any) error {
+	b, ok := r[name]
+	if !ok {
+		return fmt.Errorf("aggregation %q: %w", name, ErrNotFound)
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+// DecodeAggregation unmarshals result of the named aggregation into v, see AggregationResults.Decode.
+func (r *SearchResult) DecodeAggregation(name string, v any) error {
+	var aggs AggregationResults
+	if len(r.Aggregations) > 0 {
+		if err := json.Unmarshal(r.Aggregations, &aggs); err != nil {
+			return err
+		}
+	}
+
+	return aggs.Decode(name, v)
+}
+
+// Bucket is a bucket of multi bucket aggregation, e.g. Terms or DateHistogram.
+type Bucket struct {
+	// Key is the bucket key, see KeyString.
+	Key      json.RawMessage
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (b *Bucket) UnmarshalJSON(data []byte) error {
+	var err error
+	b.Aggregations, err = splitBucket(data, map[string]any{"key": &b.Key, "doc_count": &b.DocCount})
+	return err
+}
+
+// KeyString returns bucket key as string, numeric keys are kept as is.
+func (b Bucket) KeyString() string {
+	return bucketKey(b.Key)
+}
+
+// BucketsResult is the result of multi bucket aggregation, e.g. Terms or DateHistogram.
+type BucketsResult struct {
+	Buckets []Bucket `json:"buckets"`
+}
+
+// errNotBucket is returned when aggregation bucket isn't a JSON object.
+var errNotBucket = errors.New("aggregation bucket is not an object")
+
+// splitBucket decodes known bucket fields into pointers of fields,
+// other JSON object fields are returned as sub aggregation results.
+func splitBucket(data []byte, fields map[string]any) (AggregationResults, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, errNotBucket
+	}
+
+	var subs AggregationResults
+	for k, v := range raw {
+		if dst, ok := fields[k]; ok {
+			if err := json.Unmarshal(v, dst); err != nil {
+				return nil, fmt.Errorf("bucket %s: %w", k, err)
+			}
+			continue
+		}
+		if len(v) == 0 || v[0] != '{' {
+			continue // e.g. key_as_string
+		}
+		if subs == nil {
+			subs = make(AggregationResults)
+		}
+		subs[k] = v
+	}
+
+	return subs, nil
+}
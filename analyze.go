@@ -0,0 +1,42 @@
+package zincmetric
+
+import (
+	"context"
+	"net/http"
+)
+
+// AnalyzeToken is a token produced by an analyzer.
+type AnalyzeToken struct {
+	Token    string `json:"token"`
+	Start    int    `json:"start_offset"`
+	End      int    `json:"end_offset"`
+	Type     string `json:"type"`
+	Position int    `json:"position"`
+}
+
+// analyzeRequest is the analyze API request.
+type analyzeRequest struct {
+	Analyzer string `json:"analyzer,omitempty"`
+	Text     string `json:"text"`
+}
+
+// analyzeResponse is the analyze API response.
+type analyzeResponse struct {
+	Tokens []AnalyzeToken `json:"tokens"`
+}
+
+// Analyze returns tokens text is split into by the analyzer of client's index,
+// empty analyzer means the default one.
+func (c *Client) Analyze(ctx context.Context, analyzer, text string) ([]AnalyzeToken, error) {
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_analyze")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp analyzeResponse
+	if err := c.doJSON(ctx, http.MethodPost, endpoint, analyzeRequest{Analyzer: analyzer, Text: text}, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Tokens, nil
+}
@@ -0,0 +1,96 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+const (
+	// minAsyncPollInterval is the initial AsyncSearchHandle.Wait poll interval.
+	minAsyncPollInterval = 100 * time.Millisecond
+	// maxAsyncPollInterval caps AsyncSearchHandle.Wait poll interval backoff.
+	maxAsyncPollInterval = 2 * time.Second
+)
+
+// AsyncSearchHandle refers to a search running in the background.
+type AsyncSearchHandle struct {
+	c  *Client
+	ID string
+
+	result *SearchResult // set once search has completed
+}
+
+// asyncSearchResponse is async search submit and get response.
+type asyncSearchResponse struct {
+	ID        string         `json:"id"`
+	IsRunning bool           `json:"is_running"`
+	Response  searchResponse `json:"response"`
+}
+
+// AsyncSearch submits long-running query (full request body) and returns without waiting for results.
+func (c *Client) AsyncSearch(ctx context.Context, query json.RawMessage) (*AsyncSearchHandle, error) {
+	endpoint, err := c.endpoint("es", c.currentIndex(), "_async_search")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(query) == 0 {
+		query = json.RawMessage(`{}`)
+	}
+
+	var resp asyncSearchResponse
+	if err := c.doJSON(ctx, http.MethodPost, endpoint, query, &resp); err != nil {
+		return nil, err
+	}
+
+	h := &AsyncSearchHandle{c: c, ID: resp.ID}
+	if !resp.IsRunning {
+		h.result = resp.Response.result()
+	}
+
+	return h, nil
+}
+
+// Wait polls search status until results are ready or ctx expires.
+func (h *AsyncSearchHandle) Wait(ctx context.Context) (*SearchResult, error) {
+	if h.result != nil {
+		return h.result, nil
+	}
+
+	endpoint, err := h.c.endpoint("es", "_async_search", h.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := minAsyncPollInterval
+	for {
+		var resp asyncSearchResponse
+		if err := h.c.doJSON(ctx, http.MethodGet, endpoint, nil, &resp); err != nil {
+			return nil, err
+		}
+		if !resp.IsRunning {
+			h.result = resp.Response.result()
+			return h.result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*2, maxAsyncPollInterval)
+	}
+}
+
+// Delete cancels the search and discards its results.
+func (h *AsyncSearchHandle) Delete(ctx context.Context) error {
+	endpoint, err := h.c.endpoint("es", "_async_search", h.ID)
+	if err != nil {
+		return err
+	}
+
+	return h.c.doJSON(ctx, http.MethodDelete, endpoint, nil, nil)
+}
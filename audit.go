@@ -0,0 +1,110 @@
+package zincmetric
+
+import (
+	"bufio"
+	"os"
+	"time"
+)
+
+const (
+	// auditQueueSize is the number of audit entries queued before they are dropped.
+	auditQueueSize = 4096
+	// defaultAuditSyncInterval is how often audit log is synced to disk.
+	defaultAuditSyncInterval = time.Second
+)
+
+// auditLog appends every written document to a local file, so documents
+// lost on crash could be recovered by replaying it.
+type auditLog struct {
+	f       *os.File
+	w       *bufio.Writer
+	entries chan []byte
+}
+
+// openAuditLog opens audit log file for appending.
+func openAuditLog(path string) (*auditLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auditLog{
+		f:       f,
+		w:       bufio.NewWriter(f),
+		entries: make(chan []byte, auditQueueSize),
+	}, nil
+}
+
+// record queues document to be written to audit log as "<timestamp>\t<document>\n".
+// It never blocks, entries are dropped if audit writer can't keep up.
+func (a *auditLog) record(doc []byte) bool {
+	entry := make([]byte, 0, len(doc)+40)
+	entry = time.Now().UTC().AppendFormat(entry, time.RFC3339Nano)
+	entry = append(entry, '\t')
+	entry = append(entry, doc...)
+	entry = append(entry, '\n')
+
+	select {
+	case a.entries <- entry:
+		return true
+	default:
+		return false
+	}
+}
+
+// run writes queued entries, syncing the file every interval, until done is closed.
+func (a *auditLog) run(interval time.Duration, done <-chan struct{}, logger Logger) {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	write := func(entry []byte) {
+		if _, err := a.w.Write(entry); err != nil {
+			logger.Error("failed to write audit log", logKeyError, err)
+		}
+	}
+	sync := func() {
+		if err := a.w.Flush(); err != nil {
+			logger.Error("failed to flush audit log", logKeyError, err)
+			return
+		}
+		if err := a.f.Sync(); err != nil {
+			logger.Error("failed to sync audit log", logKeyError, err)
+		}
+	}
+
+	for {
+		select {
+		case entry := <-a.entries:
+			write(entry)
+		case <-tick.C:
+			sync()
+		case <-done:
+			for {
+				select {
+				case entry := <-a.entries:
+					write(entry)
+				default:
+					sync()
+					if err := a.f.Close(); err != nil {
+						logger.Error("failed to close audit log", logKeyError, err)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// audit records document in the audit log, if enabled.
+func (c *Client) audit(doc []byte) {
+	if c.auditLog == nil {
+		return
+	}
+
+	if !c.auditLog.record(doc) {
+		c.logger.Warn("audit log queue is full, document not recorded",
+			logKeyIndex, c.currentIndex(),
+			"dropped_bytes", len(doc),
+		)
+	}
+}
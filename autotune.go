@@ -0,0 +1,49 @@
+package zincmetric
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultAutoTuneGain is the proportional gain used by batch size auto-tuning.
+const defaultAutoTuneGain = 0.5
+
+// batchTuner holds batch size auto-tuning configuration.
+type batchTuner struct {
+	targetLatency    time.Duration
+	minSize, maxSize int
+}
+
+// validate checks auto-tuning configuration.
+func (t *batchTuner) validate() error {
+	if t.targetLatency <= 0 {
+		return fmt.Errorf("auto-tune target latency must be positive, got %s", t.targetLatency)
+	}
+	if t.minSize > t.maxSize {
+		return fmt.Errorf("auto-tune min batch size %d exceeds max batch size %d", t.minSize, t.maxSize)
+	}
+
+	return nil
+}
+
+// tuneBatchSize adjusts current batch size based on observed request latency.
+// This is a proportional controller: the further the latency is from the target,
+// the bigger the adjustment. Latency above target shrinks the batch, below grows it.
+func (c *Client) tuneBatchSize(latency time.Duration) {
+	t := c.autoTune
+	if t == nil {
+		return
+	}
+
+	size := float64(c.batchSize.Load())
+	errRatio := float64(t.targetLatency-latency) / float64(t.targetLatency)
+	// Don't let a single extremely slow request collapse the batch size at once.
+	errRatio = max(errRatio, -1)
+
+	next := int(size + c.autoTuneGain*errRatio*size)
+	if next == int(size) && errRatio > 0 {
+		next++ // Make sure small batches can still grow.
+	}
+
+	c.batchSize.Store(int64(min(max(next, t.minSize), t.maxSize)))
+}
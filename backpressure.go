@@ -0,0 +1,90 @@
+package zincmetric
+
+import (
+	"context"
+	"sync"
+)
+
+// BackpressureWriter limits number of documents waiting in client buffer,
+// blocking writes while the buffer is full instead of letting it grow unbounded.
+// It should be the only writer of the client, so buffer usage is tracked correctly.
+type BackpressureWriter struct {
+	client *Client
+	slots  chan struct{} // semaphore, each pending document holds a slot
+}
+
+// NewBackpressureWriter creates BackpressureWriter allowing up to maxBuffer pending documents.
+func NewBackpressureWriter(client *Client, maxBuffer int) *BackpressureWriter {
+	w := &BackpressureWriter{
+		client: client,
+		slots:  make(chan struct{}, max(maxBuffer, 1)),
+	}
+	client.addReleaseHook(w.release)
+
+	return w
+}
+
+// Write writes document, blocking while the buffer is full.
+func (w *BackpressureWriter) Write(data []byte) (int, error) {
+	return w.WriteContext(context.Background(), data)
+}
+
+// WriteContext writes document, blocking while the buffer is full or until ctx expires.
+func (w *BackpressureWriter) WriteContext(ctx context.Context, data []byte) (int, error) {
+	select {
+	case w.slots <- struct{}{}:
+	case <-ctx.Done():
+		return 0, &WriteError{Err: ctx.Err(), Data: data}
+	case <-w.client.closeCh:
+		return 0, &WriteError{Err: ErrClientClosed, Data: data}
+	}
+
+	n, err := w.client.WriteContext(ctx, data)
+	if err != nil {
+		<-w.slots
+	}
+
+	return n, err
+}
+
+// Capacity returns the number of documents that can be written without blocking.
+func (w *BackpressureWriter) Capacity() int {
+	return cap(w.slots) - len(w.slots)
+}
+
+// release frees slots of n documents that left the client buffer.
+func (w *BackpressureWriter) release(n int) {
+	for range n {
+		select {
+		case <-w.slots:
+		default:
+			return
+		}
+	}
+}
+
+// releaseHooks are notified about documents leaving the client buffer.
+type releaseHooks struct {
+	mu    sync.Mutex
+	hooks []func(n int)
+}
+
+// addReleaseHook registers fn to be called with the number of documents
+// that left the client buffer, either pushed or dropped, including duplicates
+// dropped by Write before entering the buffer.
+func (c *Client) addReleaseHook(fn func(n int)) {
+	c.release.mu.Lock()
+	defer c.release.mu.Unlock()
+
+	c.release.hooks = append(c.release.hooks, fn)
+}
+
+// released notifies release hooks.
+func (c *Client) released(n int) {
+	c.release.mu.Lock()
+	defer c.release.mu.Unlock()
+
+	for _, fn := range c.release.hooks {
+		fn(n)
+	}
+}
@@ -0,0 +1,120 @@
+package zincmetric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// BulkFormat is the request format used to push document batches.
+type BulkFormat int
+
+const (
+	// BulkFormatV2 posts documents as a JSON records array to /api/_bulkv2 (default).
+	BulkFormatV2 BulkFormat = iota
+	// BulkFormatV1 posts documents as NDJSON action and document pairs to /api/_bulk,
+	// used by ZincSearch versions older than 0.4.0.
+	BulkFormatV1
+)
+
+// bulkV2MinVersion is the first ZincSearch version supporting BulkFormatV2.
+var bulkV2MinVersion = [3]int{0, 4, 0}
+
+// VersionInfo describes ZincSearch service build.
+type VersionInfo struct {
+	Version    string `json:"version"`
+	Build      string `json:"build"`
+	CommitHash string `json:"commit_hash"`
+	Branch     string `json:"branch"`
+	BuildDate  string `json:"build_date"`
+}
+
+// VersionCheck returns ZincSearch service version.
+func (c *Client) VersionCheck(ctx context.Context) (*VersionInfo, error) {
+	endpoint, err := c.endpoint("version")
+	if err != nil {
+		return nil, err
+	}
+
+	var info VersionInfo
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// DetectBulkFormat returns bulk format supported by ZincSearch service version.
+func (c *Client) DetectBulkFormat(ctx context.Context) (BulkFormat, error) {
+	info, err := c.VersionCheck(ctx)
+	if err != nil {
+		return BulkFormatV2, err
+	}
+
+	version, err := parseVersion(info.Version)
+	if err != nil {
+		return BulkFormatV2, err
+	}
+
+	for i := range version {
+		if version[i] != bulkV2MinVersion[i] {
+			if version[i] < bulkV2MinVersion[i] {
+				return BulkFormatV1, nil
+			}
+			break
+		}
+	}
+
+	return BulkFormatV2, nil
+}
+
+// parseVersion parses major, minor and patch of version like "v0.4.10-rc1".
+func parseVersion(v string) ([3]int, error) {
+	var version [3]int
+
+	core, _, _ := strings.Cut(strings.TrimPrefix(v, "v"), "-")
+	parts := strings.Split(core, ".")
+	if len(parts) > len(version) {
+		return version, fmt.Errorf("invalid version %q", v)
+	}
+
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return version, fmt.Errorf("invalid version %q", v)
+		}
+		version[i] = n
+	}
+
+	return version, nil
+}
+
+// bulkInsertV1 posts a bulk of new documents to the given index using BulkFormatV1.
+func (c *Client) bulkInsertV1(ctx context.Context, index string, data [][]byte) error {
+	action, err := json.Marshal(map[string]map[string]string{"index": {"_index": index}})
+	if err != nil {
+		return err
+	}
+
+	// Body is NDJSON of action and document pairs, so documents must fit a single line.
+	buff := new(bytes.Buffer)
+	for _, doc := range data {
+		buff.Write(action)
+		buff.WriteByte('\n')
+		if err := json.Compact(buff, doc); err != nil {
+			return err
+		}
+		buff.WriteByte('\n')
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, c.ingestEndpoint(c.bulkV1DocumentsURL), buff)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
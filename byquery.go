@@ -0,0 +1,122 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// defaultMaxConflicts is the number of version conflict retries of by-query operations.
+const defaultMaxConflicts = 3
+
+// byQueryResponse is delete/update by query response.
+type byQueryResponse struct {
+	Deleted int64 `json:"deleted"`
+	Updated int64 `json:"updated"`
+}
+
+// DeleteByQuery deletes all documents matching query (full request body,
+// e.g. {"query": {...}}) and returns the number of deleted documents.
+// Requests failing with version conflict are retried, see WithMaxConflicts.
+func (c *Client) DeleteByQuery(ctx context.Context, query json.RawMessage) (int64, error) {
+	if len(query) == 0 {
+		return 0, errors.New("query is required")
+	}
+
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_delete_by_query")
+	if err != nil {
+		return 0, err
+	}
+
+	var resp byQueryResponse
+	err = c.retryOnConflict(ctx, func() error {
+		return c.doJSON(ctx, http.MethodPost, endpoint, query, &resp)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Deleted, nil
+}
+
+// retryOnConflict calls fn until it succeeds, fails with an error other than
+// version conflict (409) or the maximum number of conflicts is reached.
+func (c *Client) retryOnConflict(ctx context.Context, fn func() error) error {
+	for conflicts := 0; ; conflicts++ {
+		err := fn()
+		if !isStatus(err, http.StatusConflict) || conflicts >= c.maxConflicts {
+			return err
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+// isStatus reports whether err is a *StatusError with the given status code.
+func isStatus(err error, code int) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == code
+}
+
+// fieldUpdateScript sets all fields from params.
+const fieldUpdateScript = `for (entry in params.fields.entrySet()) { ctx._source[entry.getKey()] = entry.getValue() }`
+
+// UpdateByQuery updates all documents matching query (full request body, e.g. {"query": {...}})
+// and returns the number of updated documents. Script is either a Painless script object
+// ({"source": "...", "params": {...}} or {"id": "..."}) or a simple field update, like
+// {"status": "archived"}, setting the given fields on every matched document.
+// Requests failing with version conflict are retried, see WithMaxConflicts.
+func (c *Client) UpdateByQuery(ctx context.Context, query json.RawMessage, script json.RawMessage) (int64, error) {
+	if len(query) == 0 {
+		return 0, errors.New("query is required")
+	}
+
+	body := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(query, &body); err != nil {
+		return 0, err
+	}
+
+	s, err := updateScript(script)
+	if err != nil {
+		return 0, err
+	}
+	body["script"] = s
+
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_update_by_query")
+	if err != nil {
+		return 0, err
+	}
+
+	var resp byQueryResponse
+	err = c.retryOnConflict(ctx, func() error {
+		return c.doJSON(ctx, http.MethodPost, endpoint, body, &resp)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Updated, nil
+}
+
+// updateScript returns script as is if it's a script object, otherwise
+// it's treated as field updates and wrapped into a field update script.
+func updateScript(script json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(script, &fields); err != nil {
+		return nil, err
+	}
+
+	_, hasSource := fields["source"]
+	_, hasID := fields["id"]
+	if hasSource || hasID {
+		return script, nil
+	}
+
+	return json.Marshal(map[string]any{
+		"source": fieldUpdateScript,
+		"lang":   "painless",
+		"params": map[string]any{"fields": fields},
+	})
+}
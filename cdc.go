@@ -0,0 +1,155 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// CDCEvent types.
+const (
+	CDCCreate = "create"
+	CDCUpdate = "update"
+	CDCDelete = "delete" // document was deleted using SoftDelete
+)
+
+// cdcPageSize is the number of changed documents read per request by CDC monitor.
+const cdcPageSize = 100
+
+// CDCEvent describes a document change found by CDC monitor.
+type CDCEvent struct {
+	Type       string
+	DocumentID string
+	// Before is the previous document version, it's only known for updates when WithVersionTracking is set.
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+// StartCDCMonitor polls client's index every pollInterval for documents whose _updated_at field
+// (see WithVersionTracking) changed since the previous poll, sending an event per change made after
+// the monitor started. Failed polls are logged and retried. Returned channel is closed once ctx is cancelled.
+func (c *Client) StartCDCMonitor(ctx context.Context, pollInterval time.Duration) <-chan CDCEvent {
+	events := make(chan CDCEvent)
+
+	go func() {
+		defer close(events)
+
+		tick := time.NewTicker(pollInterval)
+		defer tick.Stop()
+
+		m := &cdcMonitor{
+			c:        c,
+			events:   events,
+			lastSeen: time.Now().UTC().Format(time.RFC3339),
+			seen:     make(map[string]bool),
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick.C:
+			}
+
+			if err := m.poll(ctx); err != nil && ctx.Err() == nil {
+				c.logger.Error("failed to poll document changes", logKeyIndex, c.currentIndex(), logKeyError, err)
+			}
+		}
+	}()
+
+	return events
+}
+
+// cdcMonitor tracks documents changes already sent by CDC monitor.
+type cdcMonitor struct {
+	c      *Client
+	events chan<- CDCEvent
+
+	lastSeen string
+	// seen holds IDs of documents sent with lastSeen update time, as following polls match them again.
+	seen map[string]bool
+}
+
+// poll sends events of documents changed at or after lastSeen.
+func (m *cdcMonitor) poll(ctx context.Context) error {
+	var qb QueryBuilder
+	query, err := json.Marshal(map[string]any{
+		"query": queryJSON{qb.Bool().Filter(qb.DateRange(updatedAtField, m.lastSeen, "", "", ""))},
+		// _id breaks ties of documents changed within the same second, so pages don't skip them.
+		"sort": []map[string]string{{updatedAtField: "asc"}, {"_id": "asc"}},
+	})
+	if err != nil {
+		return err
+	}
+
+	pages := m.c.KeysetPaginate(query, cdcPageSize)
+	for {
+		res, err := pages.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range res.Hits {
+			if err := m.send(ctx, hit); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// send sends event of the changed document unless it was already sent.
+func (m *cdcMonitor) send(ctx context.Context, hit SearchHit) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(hit.Source, &doc); err != nil {
+		return err
+	}
+
+	var updatedAt, createdAt string
+	_ = json.Unmarshal(doc[updatedAtField], &updatedAt)
+	_ = json.Unmarshal(doc[createdAtField], &createdAt)
+
+	if updatedAt == m.lastSeen && m.seen[hit.ID] {
+		return nil
+	}
+	if updatedAt != m.lastSeen {
+		m.lastSeen = updatedAt
+		m.seen = make(map[string]bool)
+	}
+	m.seen[hit.ID] = true
+
+	ev := CDCEvent{Type: CDCUpdate, DocumentID: hit.ID, After: hit.Source}
+	switch _, deleted := doc[softDeleteField]; {
+	case deleted:
+		ev.Type = CDCDelete
+	case createdAt == updatedAt:
+		ev.Type = CDCCreate
+	}
+	if ev.Type != CDCCreate && m.c.versionTracking {
+		ev.Before = m.previousVersion(ctx, hit.ID)
+	}
+
+	select {
+	case m.events <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// previousVersion returns the document version preceding the latest one, nil if it's unknown.
+func (m *cdcMonitor) previousVersion(ctx context.Context, id string) json.RawMessage {
+	versions, err := m.c.GetHistory(ctx, id)
+	if err != nil {
+		m.c.logger.Warn("failed to get document history", logKeyIndex, m.c.currentIndex(), logKeyError, err)
+		return nil
+	}
+	if len(versions) < 2 {
+		return nil
+	}
+
+	return versions[len(versions)-2]
+}
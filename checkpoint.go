@@ -0,0 +1,57 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// checkpointIndex returns name of the index holding consumer checkpoints of index, one document per consumer.
+func checkpointIndex(index string) string {
+	return index + "_checkpoints"
+}
+
+// checkpointDoc is a consumer checkpoint document.
+type checkpointDoc struct {
+	Checkpoint string    `json:"checkpoint"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Checkpoint stores position processed by the consumer (e.g. document ID or sequence number),
+// replacing its previous checkpoint. Checkpoints are kept in a separate index named <index>_checkpoints,
+// they're written without WithIngestPipeline pipeline.
+func (c *Client) Checkpoint(ctx context.Context, consumerID, checkpointID string) error {
+	endpoint, err := c.endpoint("api", checkpointIndex(c.currentIndex()), "_doc", consumerID)
+	if err != nil {
+		return err
+	}
+
+	doc := checkpointDoc{Checkpoint: checkpointID, UpdatedAt: time.Now().UTC()}
+	return c.doJSON(ctx, http.MethodPut, endpoint, doc, nil)
+}
+
+// GetCheckpoint returns the latest checkpoint stored by the consumer using Checkpoint.
+// ErrNotFound is returned if the consumer has no checkpoint.
+func (c *Client) GetCheckpoint(ctx context.Context, consumerID string) (string, error) {
+	endpoint, err := c.endpoint("api", checkpointIndex(c.currentIndex()), "_doc", consumerID)
+	if err != nil {
+		return "", err
+	}
+
+	var resp documentResponse
+	err = c.doJSON(ctx, http.MethodGet, c.readEndpoint(endpoint), nil, &resp)
+	if isStatus(err, http.StatusNotFound) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var doc checkpointDoc
+	if err := json.Unmarshal(resp.Source, &doc); err != nil {
+		return "", err
+	}
+
+	return doc.Checkpoint, nil
+}
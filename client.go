@@ -2,30 +2,119 @@ package zincmetric
 
 import (
 	"bytes"
-	"errors"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// ClientInterface is the document writing API of Client,
+// allowing it to be replaced by other implementations, like Sink.
+type ClientInterface interface {
+	io.WriteCloser
+	Stats() Stats
+}
+
+var _ ClientInterface = (*Client)(nil)
+
+// defaultMinBatchMaxWait is how long documents wait for WithMinBatchSize batch to fill up.
+const defaultMinBatchMaxWait = 10 * time.Second
+
 // Client provides io.Writer interface implementation
 // to allow writing metring to ZincSearch service.
 type Client struct {
+	host       string
 	user, pass string
-	index      string
 
-	// Option configurable
-	client        *http.Client
-	flushInterval time.Duration
+	indexMu sync.RWMutex // guards index and singleDocumentURL, see SwitchIndex
+	index   string
 
-	dataCh  chan []byte
-	closeCh chan struct{}
+	// Option configurable
+	client                *http.Client
+	pool                  *ConnectionPool
+	slowConnThreshold     time.Duration
+	flushInterval         time.Duration
+	logger                Logger
+	writeTimeout          time.Duration
+	slowWriteThreshold    time.Duration
+	sizeTracking          bool
+	sizePercentiles       []float64
+	autoTune              *batchTuner
+	autoTuneGain          float64
+	maxHealthPollInterval time.Duration
+	onFlush               func(info FlushInfo)
+	onError               func(docs [][]byte, err error)
+	transforms            []TransformFunc
+	enricher              func(ctx context.Context, doc json.RawMessage) (json.RawMessage, error)
+	enricherTimeout       time.Duration
+	contextLogger         func(ctx context.Context) Logger
+	maxBufferSize         int
+	maxBulkSize           int
+	quota                 *indexQuota
+	rateLimitHeaders      bool
+	ingestPipeline        string
+	softDeleteFilter      bool
+	flushVerification     bool
+	versionTracking       bool
+	maxQueryCost          string
+	verificationTolerance int64
+	minBatchSize          int
+	minBatchMaxWait       time.Duration
+	loadShedding          float64 // max buffer utilization, 0 disables shedding
+	auditPath             string
+	auditSyncInterval     time.Duration
+	dedupPath             string
+	dedupTTL              time.Duration
+	indexNormalizer       func(name string) string
+	queryCache            *queryCache
+	countCache            *countCache
+	requestLogger         Logger
+	requestLogMaxBytes    int
+	contentType           string
+	acceptEncoding        string
+	idempotentBulk        bool
+	autoBulkFormat        bool
+	bulkFormat            BulkFormat
+	autoRefresh           bool
+	maxConflicts          int
+	preferLocal           bool
+	warmupQueries         []json.RawMessage
+
+	dataCh     chan []envelope // documents enqueued together end up in the same buffer
+	closeCh    chan struct{}
+	closeOnce  sync.Once
+	flushCh    chan chan error // manual flush requests
+	truncateCh chan chan int   // buffer truncate requests
+	doneCh     chan struct{}   // closed once pusher thread exits
+	closeErr   error           // set by pusher thread before doneCh is closed
+
+	inflight inflightRequests
+	auditLog *auditLog
+	dedup    *persistentDedup
+
+	// Pusher state, exposed for diagnostics.
+	bufferDepth      atomic.Int64
+	lastFlush        atomic.Int64 // unix nano
+	flushing         atomic.Bool
+	rateLimitedUntil atomic.Int64 // unix nano, see WithRateLimitHeaders
+
+	sizeMu    sync.Mutex
+	sizeStats SizeStats
+
+	stats     clientStats
+	batchSize atomic.Int64 // 0 means no limit
+	release   releaseHooks
 
 	// ZincSearch endpoints (should be pre-built using buildEndpoints())
-	healthURL         string // /healthx
-	singleDocumentURL string // /api/{index}/_doc
-	bulkDocumentsURL  string // /api/_bulkv2
+	healthURL          string // /healthx
+	singleDocumentURL  string // /api/{index}/_doc
+	bulkDocumentsURL   string // /api/_bulkv2
+	bulkV1DocumentsURL string // /api/_bulk
 }
 
 // New creates a new client to export metrics to ZincSearch service.
@@ -38,20 +127,52 @@ func New(
 ) (*Client, error) {
 
 	exporter := &Client{
-		user:          user,
-		pass:          pass,
-		index:         index,
-		client:        &http.Client{},
-		flushInterval: time.Second,
-		dataCh:        make(chan []byte),
-		closeCh:       make(chan struct{}),
+		host:                  host,
+		user:                  user,
+		pass:                  pass,
+		index:                 index,
+		client:                &http.Client{},
+		flushInterval:         time.Second,
+		logger:                nopLogger{},
+		autoTuneGain:          defaultAutoTuneGain,
+		maxHealthPollInterval: defaultMaxHealthPollInterval,
+		contentType:           "application/json",
+		maxConflicts:          defaultMaxConflicts,
+		auditSyncInterval:     defaultAuditSyncInterval,
+		dedupTTL:              defaultDeduplicationTTL,
+		minBatchMaxWait:       defaultMinBatchMaxWait,
+		dataCh:                make(chan []envelope),
+		closeCh:               make(chan struct{}),
+		doneCh:                make(chan struct{}),
+		flushCh:               make(chan chan error),
+		truncateCh:            make(chan chan int),
 	}
 
 	for _, op := range ops {
 		op(exporter)
 	}
 
-	if err := exporter.buildEndpoints(host, index); err != nil {
+	exporter.index = exporter.normalizeIndex(exporter.index)
+
+	if exporter.autoTune != nil {
+		if err := exporter.autoTune.validate(); err != nil {
+			return nil, err
+		}
+		exporter.batchSize.Store(int64(exporter.autoTune.minSize))
+	} else if exporter.maxBulkSize > 0 {
+		exporter.batchSize.Store(int64(exporter.maxBulkSize))
+	}
+
+	if exporter.pool != nil {
+		exporter.useConnectionPool()
+	}
+
+	var monitor *connectionMonitor
+	if exporter.slowConnThreshold > 0 {
+		monitor = exporter.monitorConnections()
+	}
+
+	if err := exporter.buildEndpoints(host, exporter.index); err != nil {
 		return nil, err
 	}
 
@@ -59,6 +180,47 @@ func New(
 		return nil, err
 	}
 
+	if exporter.autoBulkFormat {
+		format, err := exporter.DetectBulkFormat(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("detect bulk format: %w", err)
+		}
+		exporter.bulkFormat = format
+	}
+
+	if exporter.dedupPath != "" {
+		dedup, err := openPersistentDedup(exporter.dedupPath, exporter.dedupTTL)
+		if err != nil {
+			return nil, err
+		}
+		exporter.dedup = dedup
+	}
+
+	if exporter.auditPath != "" {
+		audit, err := openAuditLog(exporter.auditPath)
+		if err != nil {
+			if exporter.dedup != nil {
+				exporter.dedup.close()
+			}
+			return nil, err
+		}
+		exporter.auditLog = audit
+		go audit.run(exporter.auditSyncInterval, exporter.doneCh, exporter.logger)
+	}
+
+	if exporter.dedup != nil {
+		go func() {
+			<-exporter.doneCh
+			if err := exporter.dedup.close(); err != nil {
+				exporter.logger.Error("failed to close deduplication database", logKeyError, err)
+			}
+		}()
+	}
+
+	if monitor != nil {
+		go monitor.run(connectionSummaryInterval, exporter.doneCh)
+	}
+
 	go exporter.run()
 
 	return exporter, nil
@@ -66,22 +228,257 @@ func New(
 
 // Write writes data to ZincSearch service.
 // Data is expected to be in JSON format.
+// Returned errors are of *WriteError type, holding the document that wasn't written.
 func (c *Client) Write(data []byte) (int, error) {
-	select {
-	case <-c.closeCh:
-		return 0, errors.New("client closed")
-	case c.dataCh <- bytes.Clone(data):
-		return len(data), nil
+	return c.WriteContext(context.Background(), data)
+}
+
+// WriteContext is Write, giving up waiting for the document to be accepted once ctx expires.
+func (c *Client) WriteContext(ctx context.Context, data []byte) (int, error) {
+	if err := c.enqueue(ctx, data, nil); err != nil {
+		return 0, &WriteError{Err: err, Data: bytes.Clone(data)}
 	}
+
+	return len(data), nil
+}
+
+// WriteBatch writes documents to ZincSearch service, all documents are
+// handed over to the pusher thread at once, so they are flushed together.
+func (c *Client) WriteBatch(docs [][]byte) error {
+	index := c.currentIndex()
+	batch := make([]envelope, 0, len(docs))
+	hashed := make([][]byte, 0, len(docs)) // documents remembered by deduplicate
+	for _, data := range docs {
+		doc, err := applyTransforms(bytes.Clone(c.enrich(context.Background(), data)), c.transforms)
+		if err != nil {
+			c.forgetDuplicates(hashed)
+			return err
+		}
+		if !c.deduplicate(doc) {
+			continue
+		}
+		hashed = append(hashed, doc)
+		if doc, err = c.trackCreated(doc); err != nil {
+			c.forgetDuplicates(hashed)
+			return err
+		}
+		if doc, err = c.assignID(doc); err != nil {
+			c.forgetDuplicates(hashed)
+			return err
+		}
+		batch = append(batch, envelope{doc: doc, index: index})
+	}
+	for _, env := range batch {
+		c.audit(env.doc)
+	}
+
+	if err := c.send(context.Background(), batch); err != nil {
+		c.forgetDuplicates(hashed)
+		return err
+	}
+
+	return nil
+}
+
+// WriteAcknowledged writes the document like WriteContext, returned channel receives nil once it's
+// pushed to ZincSearch service, or the error it was dropped with (e.g. ErrTruncated or failure to
+// flush it on Close). Flushes failing with retryable errors aren't reported, the document stays buffered.
+func (c *Client) WriteAcknowledged(ctx context.Context, data []byte) (<-chan error, error) {
+	ack := make(chan error, 1)
+	if err := c.enqueue(ctx, data, ack); err != nil {
+		return nil, &WriteError{Err: err, Data: bytes.Clone(data)}
+	}
+
+	return ack, nil
+}
+
+// enqueue hands document over to the pusher thread, ack receives the outcome of its push if set.
+func (c *Client) enqueue(ctx context.Context, data []byte, ack chan<- error) error {
+	if c.shouldShed() {
+		c.stats.documentsShed.Add(1)
+		return ErrLoadShed
+	}
+
+	doc, err := applyTransforms(bytes.Clone(c.enrich(ctx, data)), c.transforms)
+	if err != nil {
+		return err
+	}
+
+	if !c.deduplicate(doc) {
+		if ack != nil {
+			ack <- nil
+		}
+		c.released(1) // Never enters the buffer, see BackpressureWriter.
+		return nil    // Already written, pretend it was accepted.
+	}
+
+	hashed := doc
+	if doc, err = c.trackCreated(doc); err != nil {
+		c.forgetDuplicates([][]byte{hashed})
+		return err
+	}
+	if doc, err = c.assignID(doc); err != nil {
+		c.forgetDuplicates([][]byte{hashed})
+		return err
+	}
+
+	c.audit(doc)
+	env := envelope{doc: doc, index: c.currentIndex(), ack: ack}
+	if c.contextLogger != nil {
+		env.logger = c.contextLogger(ctx)
+	}
+
+	if err := c.send(ctx, []envelope{env}); err != nil {
+		c.forgetDuplicates([][]byte{hashed})
+		return err
+	}
+
+	return nil
+}
+
+// shouldShed reports whether buffer utilization is above load shedding threshold.
+func (c *Client) shouldShed() bool {
+	if c.loadShedding == 0 || c.maxBufferSize == 0 {
+		return false
+	}
+
+	return float64(c.bufferDepth.Load())/float64(c.maxBufferSize) > c.loadShedding
+}
+
+// BufferDepth returns the number of documents waiting to be pushed.
+func (c *Client) BufferDepth() int {
+	return int(c.bufferDepth.Load())
+}
+
+// send hands documents over to the pusher thread, warning about
+// slow writes and giving up after write timeout or once ctx expires.
+func (c *Client) send(ctx context.Context, docs []envelope) error {
+	var timeout, slow <-chan time.Time
+	if c.writeTimeout > 0 {
+		t := time.NewTimer(c.writeTimeout)
+		defer t.Stop()
+		timeout = t.C
+	}
+	if c.slowWriteThreshold > 0 {
+		t := time.NewTimer(c.slowWriteThreshold)
+		defer t.Stop()
+		slow = t.C
+	}
+
+	start := time.Now()
+	warned := false
+	for {
+		select {
+		case <-c.closeCh:
+			return ErrClientClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		case c.dataCh <- docs:
+			return nil
+		case <-slow:
+			c.warnSlowWrite(time.Since(start))
+			warned = true
+			slow = nil
+		case <-timeout:
+			if !warned {
+				c.warnSlowWrite(time.Since(start))
+			}
+			return ErrWriteTimeout
+		}
+	}
+}
+
+// warnSlowWrite logs a warning about Write being blocked, with enough
+// context to tell whether the pusher is stuck flushing to ZincSearch.
+func (c *Client) warnSlowWrite(waited time.Duration) {
+	var lastFlush time.Time
+	if ns := c.lastFlush.Load(); ns != 0 {
+		lastFlush = time.Unix(0, ns)
+	}
+
+	c.logger.Warn("slow metrics write",
+		logKeyIndex, c.currentIndex(),
+		"waited", waited,
+		"buffer_depth", c.bufferDepth.Load(),
+		"last_flush", lastFlush,
+		"flush_in_progress", c.flushing.Load(),
+	)
 }
 
 // Close closes the metrics client and flushes all
 // remaining metrics to ZincSearch service.
 func (c *Client) Close() error {
-	close(c.closeCh)
+	c.closeOnce.Do(func() { close(c.closeCh) })
 	return nil
 }
 
+// IsReady reports whether client is open and ZincSearch service responds to health checks.
+func (c *Client) IsReady() bool {
+	return c.isReady(context.Background())
+}
+
+// isReady is IsReady, giving up on the health check once ctx expires.
+func (c *Client) isReady(ctx context.Context) bool {
+	select {
+	case <-c.closeCh:
+		return false
+	default:
+	}
+
+	return c.pingContext(ctx) == nil
+}
+
+// Flush pushes all buffered documents to ZincSearch service without waiting for flush interval.
+func (c *Client) Flush() error {
+	done := make(chan error, 1)
+	select {
+	case <-c.closeCh:
+		return ErrClientClosed
+	case c.flushCh <- done:
+	}
+
+	return <-done
+}
+
+// Truncate discards all buffered documents without sending them to ZincSearch service
+// and returns number of discarded documents. Discarded documents are passed to
+// WithOnError callback with ErrTruncated.
+func (c *Client) Truncate() int {
+	done := make(chan int, 1)
+	select {
+	case <-c.closeCh:
+		return 0
+	case c.truncateCh <- done:
+	}
+
+	return <-done
+}
+
+// CloseGracefully stops accepting new writes, pushes all buffered documents,
+// waits for all in-flight requests to complete, rejecting new ones with
+// ErrClientClosed, and closes idle connections.
+// Unlike Close, it waits for all of that to finish or for ctx to expire.
+func (c *Client) CloseGracefully(ctx context.Context) error {
+	c.closeOnce.Do(func() { close(c.closeCh) })
+
+	select {
+	case <-c.doneCh:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-c.inflight.close():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if c.pool == nil { // Shared connections are closed with ConnectionPool.Close.
+		c.client.CloseIdleConnections()
+	}
+	return c.closeErr
+}
+
 // buildEndpoints pre-builds endpoints to be used for communicating
 // with ZincSearch service.
 func (c *Client) buildEndpoints(host, index string) error {
@@ -96,6 +493,11 @@ func (c *Client) buildEndpoints(host, index string) error {
 		return err
 	}
 
+	c.bulkV1DocumentsURL, err = url.JoinPath(host, "api", "_bulk")
+	if err != nil {
+		return err
+	}
+
 	c.healthURL, err = url.JoinPath(host, "/healthz")
 	if err != nil {
 		return err
@@ -104,31 +506,65 @@ func (c *Client) buildEndpoints(host, index string) error {
 	return nil
 }
 
-// createDocument posts a new document to ZincSearch service.
-func (c *Client) createDocument(data []byte) error {
-	req, err := http.NewRequest(http.MethodPost, c.singleDocumentURL, bytes.NewReader(data))
+// createDocument posts a new document to the given index.
+func (c *Client) createDocument(index string, data []byte) error {
+	endpoint, err := c.documentURL(index)
 	if err != nil {
 		return err
 	}
 
-	req.SetBasicAuth(c.user, c.pass)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.client.Do(req)
+	resp, err := c.do(context.Background(), http.MethodPost, c.ingestEndpoint(endpoint), bytes.NewReader(data))
 	if err != nil {
 		return err
 	}
 
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("not 200 response code: %d", resp.StatusCode)
+	return resp.Body.Close()
+}
+
+// maxBulkSplitDepth limits how many times too large bulk request is split in half.
+const maxBulkSplitDepth = 16
+
+// createBulkDocuments posts a bulk of new documents to the given index, returning the number
+// of documents pushed. Bulks rejected as too large (413) are split in half and retried.
+func (c *Client) createBulkDocuments(index string, data [][]byte) (int, error) {
+	return c.createBulkDocumentsSplit(index, data, 0)
+}
+
+// createBulkDocumentsSplit posts the bulk, bisecting it on 413 response until depth reaches
+// maxBulkSplitDepth. If a half fails, the number of documents of preceding halves that were
+// pushed is returned, so they could be removed from the buffer instead of being sent again.
+func (c *Client) createBulkDocumentsSplit(index string, data [][]byte, depth int) (int, error) {
+	if len(data) == 1 {
+		if err := c.createDocument(index, data[0]); err != nil {
+			return 0, err
+		}
+		return 1, nil
 	}
 
-	return nil
+	err := c.bulkInsert(context.Background(), index, data)
+	if err == nil {
+		return len(data), nil
+	}
+	if !isStatus(err, http.StatusRequestEntityTooLarge) || depth >= maxBulkSplitDepth {
+		return 0, err
+	}
+
+	half := len(data) / 2
+	n, err := c.createBulkDocumentsSplit(index, data[:half], depth+1)
+	if err != nil {
+		return n, err
+	}
+
+	m, err := c.createBulkDocumentsSplit(index, data[half:], depth+1)
+	return n + m, err
 }
 
-// createBulkDocuments posts a bulk of new documents to ZincSearch service.
-func (c *Client) createBulkDocuments(data [][]byte) error {
+// bulkInsert posts a bulk of new documents to the given index.
+func (c *Client) bulkInsert(ctx context.Context, index string, data [][]byte) error {
+	if c.bulkFormat == BulkFormatV1 {
+		return c.bulkInsertV1(ctx, index, data)
+	}
+
 	// Construct request body, this should be faster and simpler than unmarshaling each data peace individually.
 	// Format:
 	// {
@@ -140,7 +576,7 @@ func (c *Client) createBulkDocuments(data [][]byte) error {
 	//	]
 	// }
 	buff := new(bytes.Buffer)
-	_, err := buff.WriteString(fmt.Sprintf(`{"index":"%s","records":[`, c.index))
+	_, err := buff.WriteString(fmt.Sprintf(`{"index":"%s","records":[`, index))
 	if err != nil {
 		return err
 	}
@@ -150,31 +586,23 @@ func (c *Client) createBulkDocuments(data [][]byte) error {
 		return err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, c.bulkDocumentsURL, buff)
-	if err != nil {
-		return err
-	}
-
-	req.SetBasicAuth(c.user, c.pass)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.client.Do(req)
+	resp, err := c.do(ctx, http.MethodPost, c.ingestEndpoint(c.bulkDocumentsURL), buff)
 	if err != nil {
 		return err
 	}
 
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("not 200 response code: %d", resp.StatusCode)
-	}
-
-	return nil
+	return resp.Body.Close()
 }
 
 // ping does a health check ping to the ZincSearch /healthz endpoint.
 // Non 200 status code is treated as error.
 func (c *Client) ping() error {
-	req, err := http.NewRequest(http.MethodGet, c.healthURL, nil)
+	return c.pingContext(context.Background())
+}
+
+// pingContext is ping, giving up once ctx expires.
+func (c *Client) pingContext(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.healthURL, nil)
 	if err != nil {
 		return err
 	}
@@ -185,7 +613,7 @@ func (c *Client) ping() error {
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("not 200 response code: %d", resp.StatusCode)
+		return &StatusError{StatusCode: resp.StatusCode}
 	}
 
 	return nil
@@ -193,41 +621,169 @@ func (c *Client) ping() error {
 
 // run runs pusher tread, that gathers and pushes data to ZincSearch service.
 func (c *Client) run() {
-	buff := make([][]byte, 0)
+	buff := make([]envelope, 0)
 
 	tick := time.NewTicker(c.flushInterval)
 	defer tick.Stop()
 
+	defer close(c.doneCh)
 	defer func() {
 		// Flush remaining buffer.
-		c.createBulkDocuments(buff)
+		if buff = c.flush(buff); len(buff) > 0 {
+			c.closeErr = fmt.Errorf("failed to flush %d remaining documents", len(buff))
+			acknowledge(buff, c.closeErr)
+		}
 	}()
 
+	var bufferedSince time.Time // when the oldest buffered document was received
 	for {
+		in := c.dataCh
+		if c.maxBufferSize > 0 && len(buff) >= c.maxBufferSize {
+			in = nil // Buffer is full, writers wait until it's flushed.
+		}
+
 		select {
 		case <-c.closeCh:
 			return
-		case b := <-c.dataCh:
-			buff = append(buff, b)
+		case docs := <-in:
+			if len(buff) == 0 {
+				bufferedSince = time.Now()
+			}
+			buff = append(buff, docs...)
+			c.bufferDepth.Store(int64(len(buff)))
+			if c.maxBufferSize > 0 && len(buff) >= c.maxBufferSize {
+				buff = c.flush(buff)
+			}
 		case <-tick.C:
-			if err := c.flushBuffer(buff); err != nil {
-				// TODO: would be nice to log this, should potentially introduce Logger interface.
-				break // Don't clear the buffer in case of error.
+			if len(buff) < c.minBatchSize && time.Since(bufferedSince) < c.minBatchMaxWait {
+				continue // Wait for the batch to fill up.
+			}
+			buff = c.flush(buff)
+		case done := <-c.flushCh:
+			buff = c.flush(buff)
+			if len(buff) > 0 {
+				done <- fmt.Errorf("failed to flush %d documents", len(buff))
+			} else {
+				done <- nil
+			}
+		case done := <-c.truncateCh:
+			n := len(buff)
+			if n > 0 {
+				c.notifyError(documents(buff), ErrTruncated)
+				acknowledge(buff, ErrTruncated)
+				c.released(n)
 			}
 			buff = nil
+			c.bufferDepth.Store(0)
+			done <- n
 		}
 	}
 }
 
-// flushBuffer pushes data in buffer to ZincSearch service.
-func (c *Client) flushBuffer(buff [][]byte) error {
+// flush pushes buffered data to ZincSearch service in batches of current batch size.
+// Documents that failed to be pushed are returned, so they could be retried later.
+func (c *Client) flush(buff []envelope) []envelope {
+	if c.rateLimited() {
+		return buff // Documents stay buffered until rate limit resets.
+	}
+
+	c.flushing.Store(true)
+	defer c.flushing.Store(false)
+
+	for len(buff) > 0 {
+		n := len(buff)
+		if size := int(c.batchSize.Load()); size > 0 {
+			n = min(n, size)
+		}
+		index := buff[0].index
+		for i := 1; i < n; i++ {
+			if buff[i].index != index {
+				n = i // Documents written before SwitchIndex go to the old index.
+				break
+			}
+		}
+
+		docs := documents(buff[:n])
+		if c.quota != nil && c.quotaExceeded(index, docs) {
+			c.logger.Warn("index quota exceeded, dropping documents", logKeyIndex, index, logKeyBatchSize, n)
+			c.notifyError(docs, ErrQuotaExceeded)
+			acknowledge(buff[:n], ErrQuotaExceeded)
+			c.released(n)
+			buff = buff[n:]
+			continue
+		}
+
+		var before int64
+		if c.flushVerification {
+			before = c.verificationCount(index)
+		}
+
+		start := time.Now()
+		pushed, err := c.flushBuffer(index, docs)
+		took := time.Since(start)
+		c.tuneBatchSize(took)
+		if err != nil {
+			c.stats.flushErrors.Add(1)
+			for _, l := range batchLoggers(buff[:n], c.logger) {
+				l.Error("failed to flush metrics",
+					logKeyIndex, index,
+					logKeyDocCount, len(buff),
+					logKeyBatchSize, n,
+					logKeyError, err,
+				)
+			}
+			// Don't clear the buffer in case of error, except for documents of split bulk that were pushed.
+			c.pushed(index, buff[:pushed], took)
+			buff = buff[pushed:]
+			break
+		}
+
+		c.pushed(index, buff[:n], took)
+		if c.flushVerification {
+			c.verifyFlush(index, before, docs)
+		} else if c.autoRefresh {
+			if err := c.refresh(context.Background(), index); err != nil {
+				c.logger.Error("failed to refresh index", logKeyIndex, index, logKeyError, err)
+			}
+		}
+		buff = buff[n:]
+	}
+
+	c.bufferDepth.Store(int64(len(buff)))
 	if len(buff) == 0 {
 		return nil
 	}
 
+	return buff
+}
+
+// pushed records documents pushed to the index, removing them from the buffer.
+func (c *Client) pushed(index string, envs []envelope, took time.Duration) {
+	if len(envs) == 0 {
+		return
+	}
+
+	c.stats.documentsSent.Add(int64(len(envs)))
+	c.lastFlush.Store(time.Now().UnixNano())
+	c.recordSizes(documents(envs))
+	c.notifyFlush(FlushInfo{Index: index, Documents: len(envs), Duration: took})
+	acknowledge(envs, nil)
+	c.released(len(envs))
+}
+
+// flushBuffer pushes data in buffer to the given index, returning the number of pushed documents,
+// which may be non-zero on error when the bulk was split, see createBulkDocumentsSplit.
+func (c *Client) flushBuffer(index string, buff [][]byte) (int, error) {
+	if len(buff) == 0 {
+		return 0, nil
+	}
+
 	if len(buff) == 1 {
-		return c.createDocument(buff[0])
+		if err := c.createDocument(index, buff[0]); err != nil {
+			return 0, err
+		}
+		return 1, nil
 	}
 
-	return c.createBulkDocuments(buff)
+	return c.createBulkDocuments(index, buff)
 }
@@ -0,0 +1,129 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// CompositeSource is a source of composite aggregation bucket keys.
+type CompositeSource struct {
+	name string
+	typ  string
+	body map[string]any
+}
+
+// TermsSource keys composite buckets by field values.
+func TermsSource(name, field string) CompositeSource {
+	return CompositeSource{name: name, typ: "terms", body: map[string]any{"field": field}}
+}
+
+// HistogramSource keys composite buckets by numeric field values rounded down to interval.
+func HistogramSource(name, field string, interval float64) CompositeSource {
+	return CompositeSource{name: name, typ: "histogram", body: map[string]any{"field": field, "interval": interval}}
+}
+
+// DateHistogramSource keys composite buckets by date field values rounded down to interval,
+// see AggregationBuilder.DateHistogram.
+func DateHistogramSource(name, field, interval string) CompositeSource {
+	return CompositeSource{name: name, typ: "date_histogram", body: dateInterval(field, interval)}
+}
+
+func (s CompositeSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{s.name: map[string]any{s.typ: s.body}})
+}
+
+// Composite groups documents into buckets of all combinations of sources values, size buckets
+// at a time. Buckets following afterKey are returned, nil afterKey starts from the beginning,
+// see CompositeAggResult.AfterKey.
+func (AggregationBuilder) Composite(name string, sources []CompositeSource, size int, afterKey map[string]any) Aggregation {
+	if len(sources) == 0 {
+		return aggregation{name: name, err: errors.New("composite aggregation has no sources")}
+	}
+
+	body := map[string]any{"sources": sources}
+	if size > 0 {
+		body["size"] = size
+	}
+	if afterKey != nil {
+		body["after"] = afterKey
+	}
+
+	return aggregation{name: name, typ: "composite", body: body}
+}
+
+// CompositeAggResult is a page of composite aggregation buckets.
+type CompositeAggResult struct {
+	// AfterKey is the key of the last bucket, used to request the next page. It's nil after the last page.
+	AfterKey map[string]any    `json:"after_key"`
+	Buckets  []CompositeBucket `json:"buckets"`
+}
+
+// CompositeBucket is a bucket of composite aggregation.
+type CompositeBucket struct {
+	// Key holds bucket values keyed by source name.
+	Key      map[string]any
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (b *CompositeBucket) UnmarshalJSON(data []byte) error {
+	var err error
+	b.Aggregations, err = splitBucket(data, map[string]any{"key": &b.Key, "doc_count": &b.DocCount})
+	return err
+}
+
+// CompositeScroller pages through all buckets of composite aggregation.
+type CompositeScroller struct {
+	c       *Client
+	query   json.RawMessage
+	name    string
+	sources []CompositeSource
+	size    int
+	subs    []Aggregation
+
+	after map[string]any
+	done  bool
+}
+
+// CompositeScroll creates scroller going through composite aggregation buckets of documents
+// matching query, size buckets per page. Sub aggregations are run on each bucket.
+func (c *Client) CompositeScroll(query json.RawMessage, name string, sources []CompositeSource, size int, subs ...Aggregation) *CompositeScroller {
+	return &CompositeScroller{c: c, query: query, name: name, sources: sources, size: size, subs: subs}
+}
+
+// Next returns next page of buckets, io.EOF is returned once all buckets were read.
+func (s *CompositeScroller) Next(ctx context.Context) (*CompositeAggResult, error) {
+	if s.done {
+		return nil, io.EOF
+	}
+
+	var ab AggregationBuilder
+	agg := ab.Composite(s.name, s.sources, s.size, s.after).Sub(s.subs...)
+
+	res, err := s.c.Search(ctx, s.query, SearchAggregations(agg), noHits)
+	if err != nil {
+		return nil, err
+	}
+
+	var page CompositeAggResult
+	if err := res.DecodeAggregation(s.name, &page); err != nil {
+		return nil, err
+	}
+
+	if len(page.Buckets) == 0 {
+		s.done = true
+		return nil, io.EOF
+	}
+	s.after = page.AfterKey
+	s.done = page.AfterKey == nil
+
+	return &page, nil
+}
+
+// AfterKey returns key of the last read bucket, it can be stored to resume paging later.
+func (s *CompositeScroller) AfterKey() map[string]any {
+	return s.after
+}
@@ -0,0 +1,110 @@
+package zincmetric
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const connectionSummaryInterval = time.Minute
+
+// connectionMonitor wraps HTTP transport measuring time to first byte of every request.
+type connectionMonitor struct {
+	base          http.RoundTripper
+	slowThreshold time.Duration
+	logger        Logger
+
+	mu       sync.Mutex
+	requests int
+	slow     int
+	latency  time.Duration
+}
+
+func (m *connectionMonitor) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := m.base.RoundTrip(req)
+	latency := time.Since(start)
+
+	m.mu.Lock()
+	m.requests++
+	m.latency += latency
+	slow := latency > m.slowThreshold
+	if slow {
+		m.slow++
+	}
+	m.mu.Unlock()
+
+	if slow {
+		m.logger.Warn("slow connection",
+			"url", req.URL.Redacted(),
+			"method", req.Method,
+			"latency", latency,
+		)
+	}
+
+	return resp, err
+}
+
+// CloseIdleConnections closes idle connections of the wrapped transport, so http.Client.CloseIdleConnections works.
+func (m *connectionMonitor) CloseIdleConnections() {
+	if t, ok := m.base.(interface{ CloseIdleConnections() }); ok {
+		t.CloseIdleConnections()
+	}
+}
+
+// run logs connection summary every interval until done is closed.
+func (m *connectionMonitor) run(interval time.Duration, done <-chan struct{}) {
+	tick := time.NewTicker(interval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-tick.C:
+			m.summary()
+		}
+	}
+}
+
+func (m *connectionMonitor) summary() {
+	m.mu.Lock()
+	requests, slow, latency := m.requests, m.slow, m.latency
+	m.requests, m.slow, m.latency = 0, 0, 0
+	m.mu.Unlock()
+
+	if requests == 0 {
+		return
+	}
+
+	log := m.logger.Debug
+	if slow > 0 {
+		log = m.logger.Warn
+	}
+	log("connection summary",
+		"requests", requests,
+		"slow_connections", slow,
+		"average_latency", latency/time.Duration(requests),
+	)
+}
+
+// monitorConnections replaces client transport with connectionMonitor,
+// the HTTP client given with WithHttpClient is copied and left untouched.
+func (c *Client) monitorConnections() *connectionMonitor {
+	base := c.client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	monitor := &connectionMonitor{
+		base:          base,
+		slowThreshold: c.slowConnThreshold,
+		logger:        c.logger,
+	}
+
+	client := *c.client
+	client.Transport = monitor
+	c.client = &client
+
+	return monitor
+}
@@ -0,0 +1,53 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// WriteAndWait writes the document, pushes it to ZincSearch service and polls Search
+// with query until it finds a hit or ctx expires, so the document is searchable once it returns.
+// Search results are never served from the query cache.
+func (c *Client) WriteAndWait(ctx context.Context, data []byte, query json.RawMessage) error {
+	if _, err := c.WriteContext(ctx, data); err != nil {
+		return err
+	}
+
+	if err := c.Flush(); err != nil {
+		return err
+	}
+
+	if err := c.Refresh(ctx); err != nil {
+		return err
+	}
+
+	req, err := newSearchRequest(query, nil)
+	if err != nil {
+		return err
+	}
+
+	endpoint, body, err := req.encode(c)
+	if err != nil {
+		return err
+	}
+
+	interval := minHealthPollInterval
+	for {
+		res, err := c.search(ctx, endpoint, body)
+		if err == nil && len(res.Hits) > 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return err
+			}
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*2, c.maxHealthPollInterval)
+	}
+}
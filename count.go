@@ -0,0 +1,114 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// countResponse is count API response.
+type countResponse struct {
+	Count int64 `json:"count"`
+}
+
+// Count returns the number of documents matching query (full request body,
+// e.g. {"query": {...}}), nil query counts all documents.
+// Results are cached if WithCountCacheTTL is set.
+func (c *Client) Count(ctx context.Context, query json.RawMessage) (int64, error) {
+	index := c.currentIndex()
+	key := index + "\x00" + string(query) // so counts of the old index aren't returned after SwitchIndex
+	if c.countCache != nil {
+		if n, ok := c.countCache.get(key); ok {
+			return n, nil
+		}
+	}
+
+	var body any
+	if len(query) > 0 {
+		body = query
+	}
+	if c.softDeleteFilter {
+		req := make(map[string]json.RawMessage)
+		if len(query) > 0 {
+			if err := json.Unmarshal(query, &req); err != nil {
+				return 0, err
+			}
+		}
+		if err := excludeSoftDeleted(req); err != nil {
+			return 0, err
+		}
+		body = req
+	}
+
+	n, err := c.count(ctx, index, body)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.countCache != nil {
+		c.countCache.put(key, n)
+	}
+
+	return n, nil
+}
+
+// count returns the number of documents in index matching query request body.
+func (c *Client) count(ctx context.Context, index string, body any) (int64, error) {
+	endpoint, err := c.endpoint("api", index, "_count")
+	if err != nil {
+		return 0, err
+	}
+
+	var resp countResponse
+	if err := c.doJSON(ctx, http.MethodPost, c.readEndpoint(endpoint), body, &resp); err != nil {
+		return 0, err
+	}
+
+	return resp.Count, nil
+}
+
+// countCache caches count results by index and query.
+type countCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]countCacheEntry
+}
+
+type countCacheEntry struct {
+	count   int64
+	expires time.Time
+}
+
+func newCountCache(ttl time.Duration) *countCache {
+	return &countCache{ttl: ttl, entries: make(map[string]countCacheEntry)}
+}
+
+func (c *countCache) get(key string) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return 0, false
+	}
+
+	return entry.count, true
+}
+
+// put caches count, expired entries are evicted on the way.
+func (c *countCache) put(key string, count int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.entries[key] = countCacheEntry{count: count, expires: now.Add(c.ttl)}
+}
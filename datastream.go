@@ -0,0 +1,111 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// dataStreamMapping is the mapping of data stream backing indexes.
+var dataStreamMapping = json.RawMessage(`{"properties":{"@timestamp":{"type":"date","index":true,"sortable":true,"aggregatable":true}}}`)
+
+// dataStreamTimestampField is the date field every data stream document has.
+const dataStreamTimestampField = "@timestamp"
+
+// DataStream writes append-only time series documents to daily {name}-YYYY.MM.DD indexes.
+// Every document gets @timestamp field set to the write time, unless it already has one.
+type DataStream struct {
+	c    *Client
+	name string
+
+	mu      sync.Mutex
+	current string // current backing index
+}
+
+// NewDataStream creates data stream writing through the client, which is switched to
+// the backing index of the current day on the first write.
+func NewDataStream(client *Client, name string) *DataStream {
+	return &DataStream{c: client, name: name}
+}
+
+// IndexName returns name of the backing index holding documents of the given day.
+func (d *DataStream) IndexName(t time.Time) string {
+	return d.name + "-" + t.UTC().Format("2006.01.02")
+}
+
+// Write writes document to the backing index of the current day.
+func (d *DataStream) Write(data []byte) (int, error) {
+	if err := d.createDataStreamDocument(context.Background(), data, time.Now()); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// Close closes the underlying client.
+func (d *DataStream) Close() error {
+	return d.c.Close()
+}
+
+// createDataStreamDocument writes document stamped with now to the backing index of now.
+func (d *DataStream) createDataStreamDocument(ctx context.Context, data []byte, now time.Time) error {
+	doc, err := withTimestamp(data, now)
+	if err != nil {
+		return err
+	}
+
+	// Lock is held until the document is enqueued, so a concurrent write can't switch
+	// client to the next day's index in between.
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.useIndex(ctx, d.IndexName(now)); err != nil {
+		return err
+	}
+
+	_, err = d.c.WriteContext(ctx, doc)
+	return err
+}
+
+// useIndex switches client to the backing index, creating it if it doesn't exist yet, d.mu must be held.
+func (d *DataStream) useIndex(ctx context.Context, index string) error {
+	if d.current == index {
+		return nil
+	}
+
+	err := d.c.SwitchIndex(ctx, index)
+	if isStatus(err, http.StatusNotFound) {
+		if err := d.c.CreateIndex(ctx, index, dataStreamMapping); err != nil {
+			return err
+		}
+		err = d.c.SwitchIndex(ctx, index)
+	}
+	if err != nil {
+		return fmt.Errorf("switch to backing index %q: %w", index, err)
+	}
+
+	d.current = index
+	return nil
+}
+
+// withTimestamp adds @timestamp key to JSON object document, unless it's already set.
+func withTimestamp(doc []byte, now time.Time) ([]byte, error) {
+	var existing map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &existing); err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, errors.New("document is not a JSON object")
+	}
+	if _, ok := existing[dataStreamTimestampField]; ok {
+		return doc, nil
+	}
+
+	existing[dataStreamTimestampField], _ = json.Marshal(now.UTC().Format(time.RFC3339Nano))
+
+	return json.Marshal(existing)
+}
@@ -0,0 +1,147 @@
+package zincmetric
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// dateMathRe matches date math expressions like "now-7d/d" or "now/M".
+	dateMathRe = regexp.MustCompile(`^now([+-]\d+[yMwdhHms])*(/[yMwdhHms])?$`)
+
+	// dateFormats are date formats supported in range queries, formats can be combined using "||".
+	dateFormats = map[string]bool{
+		"strict_date_optional_time": true,
+		"date_optional_time":        true,
+		"epoch_millis":              true,
+		"epoch_second":              true,
+		"date":                      true,
+		"date_time":                 true,
+		"date_time_no_millis":       true,
+		"basic_date":                true,
+		"basic_date_time":           true,
+		"yyyy-MM-dd":                true,
+		"yyyy-MM-dd HH:mm:ss":       true,
+		"yyyy-MM-dd'T'HH:mm:ss":     true,
+	}
+)
+
+// DateRange matches documents with field date between gte and lte (inclusive), either bound
+// can be empty. Bounds can use date math, e.g. "now-7d/d" or "now/M". Format parses bounds
+// (e.g. "yyyy-MM-dd" or "epoch_millis") and timeZone (e.g. "+01:00" or "Europe/Vilnius")
+// converts them, empty values mean ZincSearch defaults.
+func (QueryBuilder) DateRange(field, gte, lte, format, timeZone string) Query {
+	body := make(map[string]string, 4)
+	if gte != "" {
+		body["gte"] = gte
+	}
+	if lte != "" {
+		body["lte"] = lte
+	}
+	if format != "" {
+		body["format"] = format
+	}
+	if timeZone != "" {
+		body["time_zone"] = timeZone
+	}
+
+	return clause{name: "range", body: map[string]any{field: body}, err: validateDateRange(gte, lte, format)}
+}
+
+// LastN matches documents with field date within the last n units ("y", "M", "w", "d", "h", "m" or "s").
+func (qb QueryBuilder) LastN(field string, n int, unit string) Query {
+	return qb.DateRange(field, fmt.Sprintf("now-%d%s", n, unit), "now", "", "")
+}
+
+// DateRangeSpec is a range of dates from From (inclusive) to To (exclusive), either bound can be
+// empty. Bounds can use date math, rounding to calendar units (e.g. "now-1M/M" to "now/M" is the
+// previous month). Key names the bucket, empty Key lets the server name it by its bounds.
+type DateRangeSpec struct {
+	Key  string `json:"key,omitempty"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// DateRange groups documents into a bucket per range of field dates. Format parses range bounds
+// and timeZone (e.g. "Europe/Vilnius") is used for date math rounding, so calendar units follow
+// local days, empty values mean ZincSearch defaults. Results decode into DateRangeAggResult.
+func (AggregationBuilder) DateRange(name, field, format string, ranges []DateRangeSpec, timeZone string) Aggregation {
+	if len(ranges) == 0 {
+		return aggregation{name: name, err: errors.New("date range aggregation has no ranges")}
+	}
+	for _, r := range ranges {
+		if err := validateDateRange(r.From, r.To, format); err != nil {
+			return aggregation{name: name, err: err}
+		}
+	}
+
+	body := map[string]any{"field": field, "ranges": ranges}
+	if format != "" {
+		body["format"] = format
+	}
+	if timeZone != "" {
+		body["time_zone"] = timeZone
+	}
+
+	return aggregation{name: name, typ: "date_range", body: body}
+}
+
+// DateRangeAggResult is the result of DateRange aggregation.
+type DateRangeAggResult struct {
+	Buckets []DateRangeBucket `json:"buckets"`
+}
+
+// DateRangeBucket holds documents within a range of dates.
+type DateRangeBucket struct {
+	Key string
+	// From and To are zero if the range is unbounded.
+	From     time.Time
+	To       time.Time
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (b *DateRangeBucket) UnmarshalJSON(data []byte) error {
+	var from, to *float64
+	subs, err := splitBucket(data, map[string]any{
+		"key": &b.Key, "from": &from, "to": &to, "doc_count": &b.DocCount,
+	})
+	if err != nil {
+		return err
+	}
+
+	if from != nil {
+		b.From = time.UnixMilli(int64(*from)).UTC()
+	}
+	if to != nil {
+		b.To = time.UnixMilli(int64(*to)).UTC()
+	}
+	b.Aggregations = subs
+	return nil
+}
+
+func validateDateRange(gte, lte, format string) error {
+	if gte == "" && lte == "" {
+		return fmt.Errorf("date range requires at least one bound")
+	}
+
+	for _, bound := range []string{gte, lte} {
+		if strings.HasPrefix(bound, "now") && !dateMathRe.MatchString(bound) {
+			return fmt.Errorf("invalid date math expression %q", bound)
+		}
+	}
+
+	if format != "" {
+		for _, f := range strings.Split(format, "||") {
+			if !dateFormats[f] {
+				return fmt.Errorf("unsupported date format %q", f)
+			}
+		}
+	}
+
+	return nil
+}
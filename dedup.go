@@ -0,0 +1,170 @@
+package zincmetric
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// defaultDeduplicationTTL is how long document hashes are remembered.
+const defaultDeduplicationTTL = 24 * time.Hour
+
+// dedupPruneEvery is the number of remembered documents after which expired hashes are removed.
+const dedupPruneEvery = 1000
+
+// dedupBucket is the bolt bucket holding document hash -> first seen unix nano.
+var dedupBucket = []byte("documents")
+
+// persistentDedup drops documents already written within TTL, remembering
+// document hashes in a local bolt database, so they survive restarts.
+type persistentDedup struct {
+	db  *bolt.DB
+	ttl time.Duration
+
+	mu      sync.Mutex
+	seen    map[[sha256.Size]byte]time.Time
+	inserts int // since the last prune
+}
+
+// openPersistentDedup opens deduplication database, loading hashes that haven't expired.
+func openPersistentDedup(path string, ttl time.Duration) (*persistentDedup, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	d := &persistentDedup{
+		db:   db,
+		ttl:  ttl,
+		seen: make(map[[sha256.Size]byte]time.Time),
+	}
+
+	now := time.Now()
+	err = db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(dedupBucket)
+		if err != nil {
+			return err
+		}
+
+		cur := b.Cursor()
+		for k, v := cur.First(); k != nil; k, v = cur.Next() {
+			seenAt := time.Unix(0, int64(binary.BigEndian.Uint64(v)))
+			if len(k) != sha256.Size || now.Sub(seenAt) > ttl {
+				if err := cur.Delete(); err != nil {
+					return err
+				}
+				continue
+			}
+			d.seen[[sha256.Size]byte(k)] = seenAt
+		}
+
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// firstSeen reports whether document wasn't seen within TTL, remembering it if so.
+func (d *persistentDedup) firstSeen(doc []byte) (bool, error) {
+	hash := sha256.Sum256(doc)
+	now := time.Now()
+
+	d.mu.Lock()
+	seenAt, ok := d.seen[hash]
+	if ok && now.Sub(seenAt) <= d.ttl {
+		d.mu.Unlock()
+		return false, nil
+	}
+	d.seen[hash] = now
+	d.inserts++
+	var expired [][sha256.Size]byte
+	if d.inserts >= dedupPruneEvery {
+		expired = d.expired(now)
+		d.inserts = 0
+	}
+	d.mu.Unlock()
+
+	value := binary.BigEndian.AppendUint64(nil, uint64(now.UnixNano()))
+	err := d.db.Batch(func(tx *bolt.Tx) error {
+		b := tx.Bucket(dedupBucket)
+		for _, h := range expired {
+			if err := b.Delete(h[:]); err != nil {
+				return err
+			}
+		}
+
+		return b.Put(hash[:], value)
+	})
+	if err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// expired removes hashes older than TTL from memory and returns them, d.mu must be held.
+func (d *persistentDedup) expired(now time.Time) [][sha256.Size]byte {
+	var hashes [][sha256.Size]byte
+	for h, seenAt := range d.seen {
+		if now.Sub(seenAt) > d.ttl {
+			delete(d.seen, h)
+			hashes = append(hashes, h)
+		}
+	}
+
+	return hashes
+}
+
+// forget removes document hash, so the document isn't considered a duplicate anymore.
+func (d *persistentDedup) forget(doc []byte) error {
+	hash := sha256.Sum256(doc)
+
+	d.mu.Lock()
+	delete(d.seen, hash)
+	d.mu.Unlock()
+
+	return d.db.Batch(func(tx *bolt.Tx) error {
+		return tx.Bucket(dedupBucket).Delete(hash[:])
+	})
+}
+
+// close closes the database.
+func (d *persistentDedup) close() error {
+	return d.db.Close()
+}
+
+// deduplicate reports whether document should be written, logging
+// deduplication database failures without dropping the document.
+func (c *Client) deduplicate(doc []byte) bool {
+	if c.dedup == nil {
+		return true
+	}
+
+	first, err := c.dedup.firstSeen(doc)
+	if err != nil {
+		c.logger.Error("failed to store document hash", logKeyIndex, c.currentIndex(), logKeyError, err)
+	}
+
+	return first
+}
+
+// forgetDuplicates makes documents that failed to be enqueued writable again, as they were
+// remembered by deduplicate before being handed over to the pusher thread.
+func (c *Client) forgetDuplicates(docs [][]byte) {
+	if c.dedup == nil {
+		return
+	}
+
+	for _, doc := range docs {
+		if err := c.dedup.forget(doc); err != nil {
+			c.logger.Error("failed to remove document hash", logKeyIndex, c.currentIndex(), logKeyError, err)
+		}
+	}
+}
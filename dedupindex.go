@@ -0,0 +1,79 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// DeduplicateIndex deletes documents of client's index sharing uniqueField value with a newer
+// document (by _created_at, see WithVersionTracking), checking up to batchSize duplicated
+// values per round until none are left. It returns the number of deleted documents and logs
+// progress at debug level. It's an offline maintenance operation, documents written meanwhile
+// may be deleted too.
+func (c *Client) DeduplicateIndex(ctx context.Context, uniqueField string, batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		return 0, errors.New("batch size must be positive")
+	}
+
+	var (
+		ab      AggregationBuilder
+		deleted int64
+	)
+	newest := json.RawMessage(`{"` + createdAtField + `": {"order": "desc", "unmapped_type": "date"}}`)
+	agg := aggregation{name: "duplicates", typ: "terms", body: map[string]any{
+		"field": uniqueField, "size": batchSize, "min_doc_count": 2,
+	}}.Sub(ab.TopHits("newest", 1, []json.RawMessage{newest}, []string{createdAtField}))
+
+	for {
+		res, err := c.Search(ctx, nil, SearchAggregations(agg), noHits)
+		if err != nil {
+			return deleted, err
+		}
+
+		groups, err := res.TopHitsGroups("duplicates", "newest")
+		if err != nil {
+			return deleted, err
+		}
+		if len(groups.Groups) == 0 {
+			return deleted, nil
+		}
+
+		var round int64
+		for _, g := range groups.Groups {
+			if len(g.Hits) == 0 {
+				continue
+			}
+
+			n, err := c.deleteDuplicates(ctx, uniqueField, g.Key, g.Hits[0].ID)
+			if err != nil {
+				return deleted, err
+			}
+			round += n
+		}
+		deleted += round
+		c.logger.Debug("deleted duplicate documents", logKeyIndex, c.currentIndex(), logKeyDocCount, deleted)
+
+		if round == 0 {
+			return deleted, nil // Nothing left that can be deleted.
+		}
+		if err := c.Refresh(ctx); err != nil {
+			return deleted, err
+		}
+	}
+}
+
+// deleteDuplicates deletes documents having field value except the one with keepID.
+func (c *Client) deleteDuplicates(ctx context.Context, field, value, keepID string) (int64, error) {
+	var qb QueryBuilder
+	q := qb.Bool().
+		Filter(qb.Term(field, value)).
+		MustNot(clause{name: "ids", body: map[string][]string{"values": {keepID}}})
+
+	query, err := json.Marshal(map[string]any{"query": queryJSON{q}})
+	if err != nil {
+		return 0, err
+	}
+
+	return c.DeleteByQuery(ctx, query)
+}
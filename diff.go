@@ -0,0 +1,74 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+// DocumentDiff is a top-level field difference between stored and new document, see Client.Diff.
+type DocumentDiff struct {
+	// Added holds fields only new document has.
+	Added map[string]json.RawMessage
+	// Removed holds fields new document doesn't have.
+	Removed []string
+	// Changed holds fields with different values.
+	Changed   map[string]Change
+	Unchanged []string
+}
+
+// Change is a field value before and after the change.
+type Change struct {
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+// Diff compares document with the given ID to newDoc, ErrNotFound is returned if it doesn't exist.
+// Field values are compared as JSON, so formatting and key order don't matter.
+func (c *Client) Diff(ctx context.Context, id string, newDoc json.RawMessage) (*DocumentDiff, error) {
+	current, err := c.GetDocument(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var before, after map[string]json.RawMessage
+	if err := json.Unmarshal(current, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(newDoc, &after); err != nil {
+		return nil, err
+	}
+
+	diff := &DocumentDiff{Added: make(map[string]json.RawMessage), Changed: make(map[string]Change)}
+	for field, b := range before {
+		a, ok := after[field]
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, field)
+		case jsonEqual(a, b):
+			diff.Unchanged = append(diff.Unchanged, field)
+		default:
+			diff.Changed[field] = Change{Before: b, After: a}
+		}
+	}
+	for field, a := range after {
+		if _, ok := before[field]; !ok {
+			diff.Added[field] = a
+		}
+	}
+	slices.Sort(diff.Removed)
+	slices.Sort(diff.Unchanged)
+
+	return diff, nil
+}
+
+// jsonEqual reports whether JSON values are equal.
+func jsonEqual(a, b json.RawMessage) bool {
+	var av, bv any
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return string(a) == string(b)
+	}
+
+	return reflect.DeepEqual(av, bv)
+}
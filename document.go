@@ -0,0 +1,240 @@
+package zincmetric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// documentResponse is get document response.
+type documentResponse struct {
+	Version int64           `json:"_version"`
+	Source  json.RawMessage `json:"_source"`
+}
+
+// GetDocument returns source of the document with the given ID, ErrNotFound if it doesn't exist.
+func (c *Client) GetDocument(ctx context.Context, id string) (json.RawMessage, error) {
+	resp, err := c.getDocument(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Source, nil
+}
+
+// getDocument returns source and version of the document with the given ID.
+func (c *Client) getDocument(ctx context.Context, id string) (*documentResponse, error) {
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_doc", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp documentResponse
+	err = c.doJSON(ctx, http.MethodGet, c.readEndpoint(endpoint), nil, &resp)
+	if isStatus(err, http.StatusNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Exists reports whether document with the given ID exists.
+func (c *Client) Exists(ctx context.Context, id string) (bool, error) {
+	_, err := c.GetDocument(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Explain returns raw explanation of how the document with the given ID
+// is scored by query (full request body, e.g. {"query": {...}}).
+func (c *Client) Explain(ctx context.Context, id string, query json.RawMessage) (json.RawMessage, error) {
+	endpoint, err := c.endpoint("es", c.currentIndex(), "_explain", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp json.RawMessage
+	if err := c.doJSON(ctx, http.MethodPost, endpoint, query, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// TermVectors returns raw term vectors (term frequencies and positions) of
+// the document fields, mostly useful for debugging search relevance.
+func (c *Client) TermVectors(ctx context.Context, id string, fields []string) (json.RawMessage, error) {
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_termvectors", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp json.RawMessage
+	err = c.doJSON(ctx, http.MethodGet, endpoint, map[string][]string{"fields": fields}, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// PutDocument creates or replaces document with the given ID.
+func (c *Client) PutDocument(ctx context.Context, id string, data []byte) error {
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_doc", id)
+	if err != nil {
+		return err
+	}
+
+	if data, err = c.keepCreated(ctx, id, data); err != nil {
+		return err
+	}
+	if data, err = c.trackUpdate(data); err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, http.MethodPut, c.ingestEndpoint(endpoint), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+
+	return c.recordVersion(id, data)
+}
+
+// DeleteDocument deletes document with the given ID, ErrNotFound is returned if it doesn't exist.
+func (c *Client) DeleteDocument(ctx context.Context, id string) error {
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_doc", id)
+	if err != nil {
+		return err
+	}
+
+	err = c.doJSON(ctx, http.MethodDelete, endpoint, nil, nil)
+	if isStatus(err, http.StatusNotFound) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// Update replaces document with the given ID.
+func (c *Client) Update(ctx context.Context, id string, data []byte) error {
+	return c.update(ctx, id, data, nil)
+}
+
+// UpdateWithVersion replaces document with the given ID using optimistic locking with
+// external versioning. *VersionConflictError (matching ErrVersionConflict) is returned
+// if the stored document version isn't lower than version.
+func (c *Client) UpdateWithVersion(ctx context.Context, id string, data []byte, version int64) error {
+	params := url.Values{
+		"version":      {strconv.FormatInt(version, 10)},
+		"version_type": {"external"},
+	}
+
+	err := c.update(ctx, id, data, params)
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusConflict {
+		return &VersionConflictError{Current: conflictVersion(statusErr.Body)}
+	}
+
+	return err
+}
+
+// Merge sets top-level fields (JSON object) on the document with the given ID, keeping its other
+// fields. ErrNotFound is returned if the document doesn't exist. Document is updated using
+// UpdateWithVersion, concurrent modifications are retried, see WithMaxConflicts.
+func (c *Client) Merge(ctx context.Context, id string, fields json.RawMessage) error {
+	var update map[string]json.RawMessage
+	if err := json.Unmarshal(fields, &update); err != nil {
+		return err
+	}
+
+	for conflicts := 0; ; conflicts++ {
+		current, err := c.getDocument(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(current.Source, &doc); err != nil {
+			return err
+		}
+		if doc == nil {
+			doc = make(map[string]json.RawMessage, len(update))
+		}
+		for k, v := range update {
+			doc[k] = v
+		}
+
+		merged, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+
+		err = c.UpdateWithVersion(ctx, id, merged, current.Version+1)
+		if !errors.Is(err, ErrVersionConflict) || conflicts >= c.maxConflicts || ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+// update sends document update request.
+func (c *Client) update(ctx context.Context, id string, data []byte, params url.Values) error {
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_update", id)
+	if err != nil {
+		return err
+	}
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	if data, err = c.keepCreated(ctx, id, data); err != nil {
+		return err
+	}
+	if data, err = c.trackUpdate(data); err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if err := resp.Body.Close(); err != nil {
+		return err
+	}
+
+	return c.recordVersion(id, data)
+}
+
+// conflictVersionRe extracts current version from version conflict error message.
+var conflictVersionRe = regexp.MustCompile(`current version \[(\d+)\]`)
+
+// conflictVersion returns current document version from version conflict error body, -1 if unknown.
+func conflictVersion(body string) int64 {
+	m := conflictVersionRe.FindStringSubmatch(body)
+	if m == nil {
+		return -1
+	}
+
+	v, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return -1
+	}
+
+	return v
+}
@@ -0,0 +1,93 @@
+package zincmetric
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// encryptedSourceField holds base64 encoded encrypted document.
+const encryptedSourceField = "_encrypted_source"
+
+// EncryptedWriter encrypts documents using AES-256-GCM before writing them to the client.
+// Only the encrypted document is stored in ZincSearch, use DecryptDocument to read it back.
+type EncryptedWriter struct {
+	client *Client
+	aead   cipher.AEAD
+}
+
+// encryptedDocument is the document stored in ZincSearch in place of original one.
+type encryptedDocument struct {
+	EncryptedSource []byte `json:"_encrypted_source"` // base64 encoded by encoding/json
+}
+
+// NewEncryptedWriter creates EncryptedWriter, key must be 32 bytes long.
+func NewEncryptedWriter(client *Client, key []byte) (*EncryptedWriter, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedWriter{client: client, aead: aead}, nil
+}
+
+// Write encrypts JSON document and writes it to the client.
+func (w *EncryptedWriter) Write(data []byte) (int, error) {
+	if !json.Valid(data) {
+		return 0, errors.New("document is not valid JSON")
+	}
+
+	nonce := make([]byte, w.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return 0, err
+	}
+
+	doc, err := json.Marshal(encryptedDocument{
+		EncryptedSource: w.aead.Seal(nonce, nonce, data, nil),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := w.client.Write(doc); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// DecryptDocument decrypts document written using EncryptedWriter.
+func DecryptDocument(key []byte, doc json.RawMessage) (json.RawMessage, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var enc encryptedDocument
+	if err := json.Unmarshal(doc, &enc); err != nil {
+		return nil, err
+	}
+	if len(enc.EncryptedSource) < aead.NonceSize() {
+		return nil, fmt.Errorf("document has no valid %s field", encryptedSourceField)
+	}
+
+	nonce, ciphertext := enc.EncryptedSource[:aead.NonceSize()], enc.EncryptedSource[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// newAEAD creates AES-256-GCM cipher.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid key size %d, AES-256 key must be 32 bytes", len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
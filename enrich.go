@@ -0,0 +1,28 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// enrich returns the document enriched by WithEnricher enricher. The original document is
+// returned if enrichment fails.
+func (c *Client) enrich(ctx context.Context, data []byte) []byte {
+	if c.enricher == nil {
+		return data
+	}
+
+	if c.enricherTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.enricherTimeout)
+		defer cancel()
+	}
+
+	doc, err := c.enricher(ctx, json.RawMessage(data))
+	if err != nil {
+		c.logger.Warn("failed to enrich document, writing it as is", logKeyIndex, c.currentIndex(), logKeyError, err)
+		return data
+	}
+
+	return doc
+}
@@ -0,0 +1,70 @@
+package zincmetric
+
+import "reflect"
+
+// envelope is a document travelling from the writer to the pusher thread,
+// together with context of the write.
+type envelope struct {
+	doc []byte
+
+	// index the document is written to, client index at the time of the write.
+	index string
+
+	// logger reports errors of the document batch, nil means client logger.
+	logger Logger
+
+	// ack receives the push outcome, see Client.WriteAcknowledged.
+	ack chan<- error
+}
+
+// acknowledge sends push outcome to envelopes waiting for it.
+func acknowledge(envs []envelope, err error) {
+	for _, env := range envs {
+		if env.ack != nil {
+			env.ack <- err
+		}
+	}
+}
+
+// documents returns documents of the envelopes.
+func documents(envs []envelope) [][]byte {
+	docs := make([][]byte, len(envs))
+	for i, env := range envs {
+		docs[i] = env.doc
+	}
+
+	return docs
+}
+
+// batchLoggers returns distinct loggers of the batch, falling back to fallback
+// for documents written without one.
+func batchLoggers(envs []envelope, fallback Logger) []Logger {
+	loggers := make([]Logger, 0, 1)
+	for _, env := range envs {
+		l := env.logger
+		if l == nil {
+			l = fallback
+		}
+		if !containsLogger(loggers, l) {
+			loggers = append(loggers, l)
+		}
+	}
+
+	return loggers
+}
+
+// containsLogger reports whether loggers contain l. Loggers of non-comparable
+// types are never considered equal.
+func containsLogger(loggers []Logger, l Logger) bool {
+	if !reflect.TypeOf(l).Comparable() {
+		return false
+	}
+
+	for _, other := range loggers {
+		if reflect.TypeOf(other) == reflect.TypeOf(l) && other == l {
+			return true
+		}
+	}
+
+	return false
+}
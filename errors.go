@@ -0,0 +1,65 @@
+package zincmetric
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrClientClosed is returned when writing to an already closed client.
+	ErrClientClosed = errors.New("client closed")
+
+	// ErrWriteTimeout is returned when a document couldn't be enqueued
+	// within the duration configured by WithWriteTimeout.
+	ErrWriteTimeout = errors.New("write timeout")
+
+	// ErrLoadShed is returned when document is rejected because buffer is too full, see WithLoadShedding.
+	ErrLoadShed = errors.New("document shed due to load")
+
+	// ErrTruncated is passed to WithOnError callback with documents discarded by Client.Truncate.
+	ErrTruncated = errors.New("buffer truncated")
+
+	// ErrQuotaExceeded is passed to WithOnError callback with documents dropped because of WithIndexQuota.
+	ErrQuotaExceeded = errors.New("index quota exceeded")
+
+	// ErrVerificationFailed is passed to WithOnError callback with flushed documents
+	// not found in the index, see WithFlushVerification.
+	ErrVerificationFailed = errors.New("flush verification failed")
+
+	// ErrQueryTooExpensive is returned by Search for queries rated over WithMaxQueryCost.
+	ErrQueryTooExpensive = errors.New("query too expensive")
+
+	// ErrNotFound is returned when requested document doesn't exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrVersionConflict is matched by *VersionConflictError.
+	ErrVersionConflict = errors.New("version conflict")
+)
+
+// WriteError is returned by Client.Write, it holds the document that failed to be written.
+type WriteError struct {
+	Err  error
+	Data []byte
+}
+
+func (e *WriteError) Error() string {
+	return "write document: " + e.Err.Error()
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// VersionConflictError is returned when document version doesn't allow the update.
+type VersionConflictError struct {
+	// Current is the stored document version, -1 if ZincSearch didn't report it.
+	Current int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s: current version %d", ErrVersionConflict, e.Current)
+}
+
+func (e *VersionConflictError) Is(target error) bool {
+	return target == ErrVersionConflict
+}
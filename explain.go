@@ -0,0 +1,58 @@
+package zincmetric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// ExplainResult is a search result with score explanation of every hit.
+type ExplainResult struct {
+	Total int64
+	Hits  []ExplainHit
+}
+
+// ExplainHit is a search hit together with explanation of its score.
+type ExplainHit struct {
+	SearchHit
+	// Explanation describes how the hit score was computed.
+	Explanation json.RawMessage `json:"_explanation"`
+}
+
+// SearchWithExplain runs query explaining score of every hit. Results are never served from the query cache.
+func (c *Client) SearchWithExplain(ctx context.Context, query json.RawMessage) (*ExplainResult, error) {
+	req, err := newSearchRequest(query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := req.set("explain", true); err != nil {
+		return nil, err
+	}
+
+	endpoint, body, err := req.encode(c)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var er struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []ExplainHit `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&er); err != nil {
+		return nil, err
+	}
+
+	return &ExplainResult{Total: er.Hits.Total.Value, Hits: er.Hits.Hits}, nil
+}
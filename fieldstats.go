@@ -0,0 +1,77 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// FieldStat is a profile of field values across index documents.
+type FieldStat struct {
+	// Min, Max and Avg are nil if field has no numeric values.
+	Min *float64
+	Max *float64
+	Avg *float64
+	// DistinctCount is the approximate number of distinct values.
+	DistinctCount int64
+	// NullRate is the share of documents (0-1) having no field value.
+	NullRate float64
+}
+
+// FieldStats profiles values of every field of client's index using a single aggregation query.
+func (c *Client) FieldStats(ctx context.Context, fields []string) (map[string]FieldStat, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("no fields to profile")
+	}
+
+	var ab AggregationBuilder
+	aggs := make([]Aggregation, 0, 3*len(fields))
+	for i, field := range fields {
+		aggs = append(aggs,
+			ab.Stats(fmt.Sprintf("f%d_stats", i), field),
+			ab.Cardinality(fmt.Sprintf("f%d_distinct", i), field, 0),
+			ab.Missing(fmt.Sprintf("f%d_missing", i), field),
+		)
+	}
+	profile := func(r *searchRequest) error {
+		if err := r.set("size", 0); err != nil {
+			return err
+		}
+		return r.set("track_total_hits", true)
+	}
+
+	res, err := c.Search(ctx, nil, SearchAggregations(aggs...), profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var results AggregationResults
+	if len(res.Aggregations) > 0 {
+		if err := json.Unmarshal(res.Aggregations, &results); err != nil {
+			return nil, err
+		}
+	}
+
+	stats := make(map[string]FieldStat, len(fields))
+	for i, field := range fields {
+		var (
+			s        StatsResult
+			distinct CardinalityResult
+			missing  MissingAggResult
+		)
+		for name, v := range map[string]any{"_stats": &s, "_distinct": &distinct, "_missing": &missing} {
+			if err := results.Decode(fmt.Sprintf("f%d%s", i, name), v); err != nil {
+				return nil, fmt.Errorf("field %q: %w", field, err)
+			}
+		}
+
+		stat := FieldStat{Min: s.Min, Max: s.Max, Avg: s.Avg, DistinctCount: distinct.Value}
+		if res.Total > 0 {
+			stat.NullRate = float64(missing.DocCount) / float64(res.Total)
+		}
+		stats[field] = stat
+	}
+
+	return stats, nil
+}
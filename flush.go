@@ -0,0 +1,24 @@
+package zincmetric
+
+import "time"
+
+// FlushInfo describes a batch of documents successfully pushed to ZincSearch service.
+type FlushInfo struct {
+	Index     string
+	Documents int
+	Duration  time.Duration
+}
+
+// notifyFlush calls WithOnFlush callback, if set.
+func (c *Client) notifyFlush(info FlushInfo) {
+	if c.onFlush != nil {
+		c.onFlush(info)
+	}
+}
+
+// notifyError calls WithOnError callback, if set.
+func (c *Client) notifyError(docs [][]byte, err error) {
+	if c.onError != nil {
+		c.onError(docs, err)
+	}
+}
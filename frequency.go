@@ -0,0 +1,142 @@
+package zincmetric
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	// frequencyTermsSize is the number of most frequent values compared between periods.
+	frequencyTermsSize = 1000
+	// significantZScore is the two-proportion z-test score of significant frequency changes (~99.7% confidence).
+	significantZScore = 3
+)
+
+// TimeRange is a period of documents with date Field from From (inclusive) to To (exclusive).
+type TimeRange struct {
+	Field string
+	From  time.Time
+	To    time.Time
+}
+
+// FrequencyReport describes field value frequencies in a period compared to the previous period of equal length.
+type FrequencyReport struct {
+	// Top holds the most frequent values of the period.
+	Top []TermFreq
+	// New holds values that didn't appear in the previous period.
+	New []string
+	// Disappeared holds values of the previous period that didn't appear in the period.
+	Disappeared []string
+	// Changes holds values whose share of documents changed significantly, biggest change first.
+	Changes []FrequencyChange
+}
+
+// FrequencyChange is a significant change of value frequency between periods.
+type FrequencyChange struct {
+	Term          string
+	PreviousCount int64
+	Count         int64
+	// ZScore is the two-proportion z-test score, positive if the value got more frequent.
+	ZScore float64
+}
+
+// FieldFrequencyAnalysis reports topN most frequent field values in timeRange, comparing frequencies
+// of up to 1000 most frequent values with the previous period of equal length.
+func (c *Client) FieldFrequencyAnalysis(ctx context.Context, field string, topN int, timeRange TimeRange) (*FrequencyReport, error) {
+	if !timeRange.From.Before(timeRange.To) {
+		return nil, errors.New("time range from must be before to")
+	}
+
+	var ab AggregationBuilder
+	prevFrom := timeRange.From.Add(-timeRange.To.Sub(timeRange.From))
+	agg := ab.Filters("periods", map[string]Query{
+		"current":  periodQuery(timeRange.Field, timeRange.From, timeRange.To),
+		"previous": periodQuery(timeRange.Field, prevFrom, timeRange.From),
+	}, ab.Terms("values", field, max(topN, frequencyTermsSize)))
+
+	res, err := c.Search(ctx, nil, SearchAggregations(agg), noHits)
+	if err != nil {
+		return nil, err
+	}
+
+	var periods FiltersAggResult
+	if err := res.DecodeAggregation("periods", &periods); err != nil {
+		return nil, err
+	}
+	var current, previous BucketsResult
+	if err := periods.Buckets["current"].Aggregations.Decode("values", &current); err != nil {
+		return nil, err
+	}
+	if err := periods.Buckets["previous"].Aggregations.Decode("values", &previous); err != nil {
+		return nil, err
+	}
+
+	return frequencyReport(current.Buckets, previous.Buckets,
+		periods.Buckets["current"].DocCount, periods.Buckets["previous"].DocCount, topN), nil
+}
+
+// periodQuery matches documents with field date from from (inclusive) to to (exclusive).
+func periodQuery(field string, from, to time.Time) Query {
+	return clause{name: "range", body: map[string]any{field: map[string]string{
+		"gte": from.UTC().Format(time.RFC3339Nano),
+		"lt":  to.UTC().Format(time.RFC3339Nano),
+	}}}
+}
+
+// frequencyReport compares value buckets of the current and previous periods having total documents each.
+func frequencyReport(current, previous []Bucket, total, prevTotal int64, topN int) *FrequencyReport {
+	prevCounts := make(map[string]int64, len(previous))
+	for _, b := range previous {
+		prevCounts[b.KeyString()] = b.DocCount
+	}
+
+	report := &FrequencyReport{}
+	seen := make(map[string]bool, len(current))
+	for i, b := range current {
+		term := b.KeyString()
+		seen[term] = true
+		if i < topN {
+			report.Top = append(report.Top, TermFreq{Term: term, DocCount: b.DocCount})
+		}
+
+		prev, ok := prevCounts[term]
+		if !ok {
+			report.New = append(report.New, term)
+		}
+		if z := proportionZScore(b.DocCount, total, prev, prevTotal); math.Abs(z) >= significantZScore {
+			report.Changes = append(report.Changes, FrequencyChange{Term: term, PreviousCount: prev, Count: b.DocCount, ZScore: z})
+		}
+	}
+	for _, b := range previous {
+		if term := b.KeyString(); !seen[term] {
+			report.Disappeared = append(report.Disappeared, term)
+		}
+	}
+	slices.Sort(report.Disappeared)
+	sort.Slice(report.Changes, func(i, j int) bool {
+		return math.Abs(report.Changes[i].ZScore) > math.Abs(report.Changes[j].ZScore)
+	})
+
+	return report
+}
+
+// proportionZScore returns two-proportion z-test score of count/total against prevCount/prevTotal.
+func proportionZScore(count, total, prevCount, prevTotal int64) float64 {
+	if total == 0 || prevTotal == 0 {
+		return 0
+	}
+
+	p1 := float64(count) / float64(total)
+	p0 := float64(prevCount) / float64(prevTotal)
+	pooled := float64(count+prevCount) / float64(total+prevTotal)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(total) + 1/float64(prevTotal)))
+	if se == 0 {
+		return 0
+	}
+
+	return (p1 - p0) / se
+}
@@ -0,0 +1,69 @@
+package zincmetric
+
+import "errors"
+
+// GeoRange is a range of distances in km, zero To means no upper bound.
+type GeoRange struct {
+	From float64
+	To   float64
+}
+
+// GeoDistance groups documents into a bucket per range of distances between field geo point and origin.
+// Results decode into GeoDistanceAggResult.
+func (AggregationBuilder) GeoDistance(name, field string, origin GeoPoint, ranges []GeoRange) Aggregation {
+	if len(ranges) == 0 {
+		return aggregation{name: name, err: errors.New("geo distance aggregation has no ranges")}
+	}
+
+	specs := make([]map[string]float64, len(ranges))
+	for i, r := range ranges {
+		spec := map[string]float64{"from": r.From}
+		if r.To > 0 {
+			spec["to"] = r.To
+		}
+		specs[i] = spec
+	}
+
+	return aggregation{name: name, typ: "geo_distance", body: map[string]any{
+		"field":  field,
+		"origin": origin,
+		"unit":   "km",
+		"ranges": specs,
+	}}
+}
+
+// GeoDistanceAggResult is the result of GeoDistance aggregation.
+type GeoDistanceAggResult struct {
+	Buckets []GeoDistanceBucket `json:"buckets"`
+}
+
+// GeoDistanceBucket holds documents within a range of distances in km.
+type GeoDistanceBucket struct {
+	Key string
+	// From and To are nil if the range is unbounded.
+	From     *float64
+	To       *float64
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (b *GeoDistanceBucket) UnmarshalJSON(data []byte) error {
+	var err error
+	b.Aggregations, err = splitBucket(data, map[string]any{
+		"key": &b.Key, "from": &b.From, "to": &b.To, "doc_count": &b.DocCount,
+	})
+	return err
+}
+
+// GeoCentroid computes the center of field geo points, results decode into GeoCentroidResult.
+func (AggregationBuilder) GeoCentroid(name, field string) Aggregation {
+	return fieldAggregation(name, "geo_centroid", field)
+}
+
+// GeoCentroidResult is the result of GeoCentroid aggregation.
+type GeoCentroidResult struct {
+	// Location is zero if Count is 0.
+	Location GeoPoint `json:"location"`
+	Count    int64    `json:"count"`
+}
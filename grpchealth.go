@@ -0,0 +1,63 @@
+//go:build grpc
+
+package zincmetric
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthWatchInterval is how often HealthServer.Watch checks client readiness.
+const healthWatchInterval = time.Second
+
+// HealthServer implements gRPC health checking protocol reporting client readiness.
+type HealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	client *Client
+}
+
+// GRPCHealthServer returns gRPC health server reporting SERVING while client is ready, see Client.IsReady.
+func (c *Client) GRPCHealthServer() *HealthServer {
+	return &HealthServer{client: c}
+}
+
+func (s *HealthServer) status() grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if s.client.IsReady() {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
+
+// Check returns current serving status.
+func (s *HealthServer) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return &grpc_health_v1.HealthCheckResponse{Status: s.status()}, nil
+}
+
+// Watch sends current serving status and then every change of it until stream is closed.
+func (s *HealthServer) Watch(_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	tick := time.NewTicker(healthWatchInterval)
+	defer tick.Stop()
+
+	last := grpc_health_v1.HealthCheckResponse_UNKNOWN
+	for {
+		if current := s.status(); current != last {
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case <-tick.C:
+		}
+	}
+}
+
+var _ grpc_health_v1.HealthServer = (*HealthServer)(nil)
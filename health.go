@@ -0,0 +1,49 @@
+package zincmetric
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const (
+	// minHealthPollInterval is the initial WaitForIndexGreen poll interval.
+	minHealthPollInterval = 100 * time.Millisecond
+	// defaultMaxHealthPollInterval caps WaitForIndexGreen poll interval backoff.
+	defaultMaxHealthPollInterval = 5 * time.Second
+)
+
+// indexHealth is the cluster health API response.
+type indexHealth struct {
+	Status string `json:"status"`
+}
+
+// WaitForIndexGreen polls ZincSearch cluster health API until client's
+// index reports green status or ctx expires. Useful after operations
+// that take time to complete, like index creation.
+func (c *Client) WaitForIndexGreen(ctx context.Context) error {
+	endpoint, err := c.endpoint("es", "_cluster", "health", c.currentIndex())
+	if err != nil {
+		return err
+	}
+
+	interval := minHealthPollInterval
+	for {
+		var health indexHealth
+		err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &health)
+		if err == nil && health.Status == "green" {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return err
+			}
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		interval = min(interval*2, c.maxHealthPollInterval)
+	}
+}
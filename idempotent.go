@@ -0,0 +1,45 @@
+package zincmetric
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+)
+
+// assignID adds a random UUID as document _id when WithIdempotentBulk is enabled,
+// so retried bulk requests overwrite already indexed documents instead of duplicating them.
+// Documents that already have an _id are left untouched.
+func (c *Client) assignID(doc []byte) ([]byte, error) {
+	if !c.idempotentBulk {
+		return doc, nil
+	}
+
+	var existing struct {
+		ID json.RawMessage `json:"_id"`
+	}
+	if err := json.Unmarshal(doc, &existing); err != nil {
+		return nil, err
+	}
+	if existing.ID != nil {
+		return doc, nil
+	}
+
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return withDocumentID(doc, id)
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,223 @@
+package zincmetric
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// importBatcher collects imported documents, writing them using WriteBatch once the batch is full.
+type importBatcher struct {
+	c       *Client
+	batch   [][]byte
+	written int64
+}
+
+// newImportBatcher creates batcher with batch size of WithMaxBufferSize (default: 1000).
+func (c *Client) newImportBatcher() *importBatcher {
+	size := c.maxBufferSize
+	if size <= 0 {
+		size = defaultScanSize
+	}
+
+	return &importBatcher{c: c, batch: make([][]byte, 0, size)}
+}
+
+// add adds document to the batch, writing the batch if it's full.
+func (b *importBatcher) add(doc []byte) error {
+	b.batch = append(b.batch, doc)
+	if len(b.batch) < cap(b.batch) {
+		return nil
+	}
+
+	return b.flush()
+}
+
+// flush writes collected documents.
+func (b *importBatcher) flush() error {
+	if len(b.batch) == 0 {
+		return nil
+	}
+
+	if err := b.c.WriteBatch(b.batch); err != nil {
+		return err
+	}
+
+	b.written += int64(len(b.batch))
+	b.batch = b.batch[:0]
+	return nil
+}
+
+// IndexFromRows writes a document produced by mapper for every row. Documents are written using
+// WriteBatch in batches of WithMaxBufferSize (default: 1000). Returns the number of written documents.
+func (c *Client) IndexFromRows(ctx context.Context, rows *sql.Rows, mapper func(cols []string, vals []any) (json.RawMessage, error)) (int64, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	b := c.newImportBatcher()
+	vals := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+
+	for row := 0; rows.Next(); row++ {
+		if err := ctx.Err(); err != nil {
+			return b.written, err
+		}
+
+		if err := rows.Scan(ptrs...); err != nil {
+			return b.written, fmt.Errorf("scan row %d: %w", row, err)
+		}
+
+		doc, err := mapper(cols, vals)
+		if err != nil {
+			return b.written, fmt.Errorf("map row %d: %w", row, err)
+		}
+
+		if err := b.add(doc); err != nil {
+			return b.written, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return b.written, err
+	}
+
+	return b.written, b.flush()
+}
+
+// CSVOptions configures IndexFromCSV.
+type CSVOptions struct {
+	// Delimiter separates fields, 0 means comma.
+	Delimiter rune
+	// HasHeader makes the first record provide field names, unless FieldNames are set.
+	HasHeader bool
+	// FieldNames name the fields, fields without names are named by their position (col0, col1, ...).
+	FieldNames []string
+	// TypeHints convert field values by field name, "int", "float" or "bool"; other fields stay strings.
+	TypeHints map[string]string
+}
+
+// IndexFromCSV writes a document for every CSV record of r, with record values keyed by field names.
+// Documents are written using WriteBatch in batches of WithMaxBufferSize (default: 1000).
+// Returns the number of written documents.
+func (c *Client) IndexFromCSV(ctx context.Context, r io.Reader, opts CSVOptions) (int64, error) {
+	cr := csv.NewReader(r)
+	if opts.Delimiter != 0 {
+		cr.Comma = opts.Delimiter
+	}
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	names := opts.FieldNames
+	if opts.HasHeader {
+		header, err := cr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, nil
+			}
+			return 0, err
+		}
+		if names == nil {
+			names = slices.Clone(header)
+		}
+	}
+
+	b := c.newImportBatcher()
+	for {
+		if err := ctx.Err(); err != nil {
+			return b.written, err
+		}
+
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return b.written, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		doc := make(map[string]any, len(record))
+		for i, v := range record {
+			name := fmt.Sprintf("col%d", i)
+			if i < len(names) {
+				name = names[i]
+			}
+
+			if doc[name], err = csvValue(v, opts.TypeHints[name]); err != nil {
+				return b.written, fmt.Errorf("line %d field %q: %w", line, name, err)
+			}
+		}
+
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return b.written, err
+		}
+
+		if err := b.add(data); err != nil {
+			return b.written, err
+		}
+	}
+
+	return b.written, b.flush()
+}
+
+// csvValue converts CSV value according to type hint.
+func csvValue(v, hint string) (any, error) {
+	switch hint {
+	case "int":
+		return strconv.ParseInt(v, 10, 64)
+	case "float":
+		return strconv.ParseFloat(v, 64)
+	case "bool":
+		return strconv.ParseBool(v)
+	default:
+		return v, nil
+	}
+}
+
+// IndexFromJSONArray writes every element of JSON array read from r as a document, streaming
+// the array without reading it into memory at once. Documents are written using WriteBatch in
+// batches of WithMaxBufferSize (default: 1000). Returns the number of written documents.
+func (c *Client) IndexFromJSONArray(ctx context.Context, r io.Reader) (int64, error) {
+	d := json.NewDecoder(r)
+
+	tok, err := d.Token()
+	if err != nil {
+		return 0, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return 0, fmt.Errorf("expected JSON array, got %v", tok)
+	}
+
+	b := c.newImportBatcher()
+	for i := 0; d.More(); i++ {
+		if err := ctx.Err(); err != nil {
+			return b.written, err
+		}
+
+		var doc json.RawMessage
+		if err := d.Decode(&doc); err != nil {
+			return b.written, fmt.Errorf("element %d: %w", i, err)
+		}
+
+		if err := b.add(doc); err != nil {
+			return b.written, err
+		}
+	}
+
+	if _, err := d.Token(); err != nil {
+		return b.written, err
+	}
+
+	return b.written, b.flush()
+}
@@ -0,0 +1,320 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// createIndexRequest is the ZincSearch create index request body.
+type createIndexRequest struct {
+	Name     string          `json:"name"`
+	Mappings json.RawMessage `json:"mappings,omitempty"`
+}
+
+// CreateIndex creates a new index with the given mapping, nil mapping
+// leaves field types to be detected by ZincSearch.
+// Index is warmed up afterwards if WithIndexWarming is set.
+func (c *Client) CreateIndex(ctx context.Context, name string, mapping json.RawMessage) error {
+	endpoint, err := c.endpoint("api", "index")
+	if err != nil {
+		return err
+	}
+
+	name = c.normalizeIndex(name)
+	err = c.doJSON(ctx, http.MethodPost, endpoint, createIndexRequest{Name: name, Mappings: mapping}, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.warmIndex(ctx, name, c.warmupQueries)
+}
+
+// DeleteIndex deletes the index with all its documents.
+func (c *Client) DeleteIndex(ctx context.Context, name string) error {
+	endpoint, err := c.endpoint("api", "index", c.normalizeIndex(name))
+	if err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodDelete, endpoint, nil, nil)
+}
+
+// LowercaseNormalizer is index name normalizer converting names to lower case.
+func LowercaseNormalizer(name string) string {
+	return strings.ToLower(name)
+}
+
+// normalizeIndex applies WithIndexNameNormalizer normalizer to index name.
+func (c *Client) normalizeIndex(name string) string {
+	if c.indexNormalizer == nil {
+		return name
+	}
+
+	return c.indexNormalizer(name)
+}
+
+// aliasIndexes returns indexes the alias points to, nil if alias doesn't exist.
+func (c *Client) aliasIndexes(ctx context.Context, alias string) ([]string, error) {
+	endpoint, err := c.endpoint("es", "_alias", alias)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp map[string]json.RawMessage
+	err = c.doJSON(ctx, http.MethodGet, endpoint, nil, &resp)
+	if isStatus(err, http.StatusNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]string, 0, len(resp))
+	for index := range resp {
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+// aliasAction is a single action of atomic alias update.
+type aliasAction map[string]map[string]string
+
+// updateAliases applies alias actions atomically.
+func (c *Client) updateAliases(ctx context.Context, actions ...aliasAction) error {
+	endpoint, err := c.endpoint("es", "_aliases")
+	if err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodPost, endpoint, map[string][]aliasAction{"actions": actions}, nil)
+}
+
+// IndexStats holds index document count and storage usage.
+type IndexStats struct {
+	DocCount    int64
+	StorageSize int64 // bytes
+}
+
+// indexResponse is the ZincSearch get index response.
+type indexResponse struct {
+	Name  string `json:"name"`
+	Stats struct {
+		DocNum      int64 `json:"doc_num"`
+		StorageSize int64 `json:"storage_size"`
+	} `json:"stats"`
+}
+
+// IndexStats returns client's index statistics.
+func (c *Client) IndexStats(ctx context.Context) (*IndexStats, error) {
+	return c.indexStats(ctx, c.currentIndex())
+}
+
+// indexStats returns the index statistics.
+func (c *Client) indexStats(ctx context.Context, index string) (*IndexStats, error) {
+	endpoint, err := c.endpoint("api", "index", index)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp indexResponse
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &IndexStats{DocCount: resp.Stats.DocNum, StorageSize: resp.Stats.StorageSize}, nil
+}
+
+// RolloverConditions triggers index rollover once any of the set conditions is met.
+type RolloverConditions struct {
+	MaxDocs int64  `json:"max_docs,omitempty"`
+	MaxSize string `json:"max_size,omitempty"` // e.g. "10gb"
+	MaxAge  string `json:"max_age,omitempty"`  // e.g. "7d"
+}
+
+// rolloverResponse is the index rollover response.
+type rolloverResponse struct {
+	RolledOver bool   `json:"rolled_over"`
+	NewIndex   string `json:"new_index"`
+}
+
+// Rollover creates a new index behind client's index alias if any of the conditions is met.
+// Returns whether rollover happened and the name of the new index.
+func (c *Client) Rollover(ctx context.Context, conditions RolloverConditions) (bool, string, error) {
+	endpoint, err := c.endpoint("es", c.currentIndex(), "_rollover")
+	if err != nil {
+		return false, "", err
+	}
+
+	var resp rolloverResponse
+	err = c.doJSON(ctx, http.MethodPost, endpoint, map[string]RolloverConditions{"conditions": conditions}, &resp)
+	if err != nil {
+		return false, "", err
+	}
+
+	if !resp.RolledOver {
+		return false, "", nil
+	}
+
+	return true, resp.NewIndex, nil
+}
+
+// Refresh makes all documents written to client's index so far visible to searches.
+func (c *Client) Refresh(ctx context.Context) error {
+	return c.refresh(ctx, c.currentIndex())
+}
+
+// refresh makes all documents written to index so far visible to searches.
+func (c *Client) refresh(ctx context.Context, index string) error {
+	endpoint, err := c.endpoint("api", index, "_refresh")
+	if err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodPost, endpoint, nil, nil)
+}
+
+// SetMapping sets client's index mapping.
+func (c *Client) SetMapping(ctx context.Context, mapping json.RawMessage) error {
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_mapping")
+	if err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodPut, endpoint, mapping, nil)
+}
+
+// GetMapping returns client's index mapping.
+func (c *Client) GetMapping(ctx context.Context) (json.RawMessage, error) {
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_mapping")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp map[string]struct {
+		Mappings json.RawMessage `json:"mappings"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp[c.currentIndex()].Mappings, nil
+}
+
+// ListIndexes returns names of all indexes.
+func (c *Client) ListIndexes(ctx context.Context) ([]string, error) {
+	endpoint, err := c.endpoint("api", "index_name")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &names); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// UpdateSettings updates client's index settings, e.g. {"index": {"refresh_interval": "-1"}}.
+func (c *Client) UpdateSettings(ctx context.Context, settings json.RawMessage) error {
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_settings")
+	if err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodPut, endpoint, settings, nil)
+}
+
+// SetRefreshInterval sets client's index refresh interval (e.g. "1s"), "-1" disables refreshes,
+// which speeds up bulk imports.
+func (c *Client) SetRefreshInterval(ctx context.Context, interval string) error {
+	return c.updateIndexSetting(ctx, "refresh_interval", interval)
+}
+
+// SetNumberOfReplicas sets client's index number of replicas.
+func (c *Client) SetNumberOfReplicas(ctx context.Context, n int) error {
+	return c.updateIndexSetting(ctx, "number_of_replicas", n)
+}
+
+// SetReplicationFactor sets number of client's index replicas (0 means no replicas)
+// and waits for the index to become green, see WaitForIndexGreen.
+func (c *Client) SetReplicationFactor(ctx context.Context, factor int) error {
+	if factor < 0 {
+		return fmt.Errorf("invalid replication factor %d", factor)
+	}
+
+	if err := c.SetNumberOfReplicas(ctx, factor); err != nil {
+		return err
+	}
+
+	return c.WaitForIndexGreen(ctx)
+}
+
+// GetReplicationFactor returns number of client's index replicas.
+func (c *Client) GetReplicationFactor(ctx context.Context) (int, error) {
+	endpoint, err := c.endpoint("api", c.currentIndex(), "_settings")
+	if err != nil {
+		return 0, err
+	}
+
+	type indexSettings struct {
+		Replicas json.RawMessage `json:"number_of_replicas"`
+	}
+	var resp map[string]struct {
+		Settings struct {
+			indexSettings
+			Index indexSettings `json:"index"` // settings may be nested under "index" key
+		} `json:"settings"`
+	}
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &resp); err != nil {
+		return 0, err
+	}
+
+	settings := resp[c.currentIndex()].Settings
+	replicas := settings.Replicas
+	if replicas == nil {
+		replicas = settings.Index.Replicas
+	}
+
+	// Replicas can be reported either as number or as string.
+	n, err := strconv.Atoi(strings.Trim(string(replicas), `"`))
+	if err != nil {
+		return 0, fmt.Errorf("parse number of replicas %s: %w", replicas, err)
+	}
+
+	return n, nil
+}
+
+// SetSimilarity defines similarity module name of client's index, e.g. config {"type": "BM25", "k1": 1.2, "b": 0.3}.
+// Fields use it once set using SetFieldSimilarity.
+func (c *Client) SetSimilarity(ctx context.Context, name string, config json.RawMessage) error {
+	return c.updateIndexSetting(ctx, "similarity", map[string]json.RawMessage{name: config})
+}
+
+// SetFieldSimilarity maps field of fieldType (e.g. "text") to be scored using similarity,
+// either built in ("BM25" or "boolean") or defined using SetSimilarity.
+func (c *Client) SetFieldSimilarity(ctx context.Context, field, fieldType, similarity string) error {
+	mapping, err := json.Marshal(map[string]any{"properties": map[string]any{
+		field: map[string]string{"type": fieldType, "similarity": similarity},
+	}})
+	if err != nil {
+		return err
+	}
+
+	return c.SetMapping(ctx, mapping)
+}
+
+// updateIndexSetting updates a single index setting.
+func (c *Client) updateIndexSetting(ctx context.Context, key string, value any) error {
+	settings, err := json.Marshal(map[string]map[string]any{"index": {key: value}})
+	if err != nil {
+		return err
+	}
+
+	return c.UpdateSettings(ctx, settings)
+}
@@ -0,0 +1,43 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// CreateIngestPipeline creates or replaces ingest pipeline transforming documents on the server, e.g.
+// {"processors": [{"set": {"field": "source", "value": "metrics"}}]}.
+func (c *Client) CreateIngestPipeline(ctx context.Context, id string, pipeline json.RawMessage) error {
+	endpoint, err := c.endpoint("es", "_ingest", "pipeline", id)
+	if err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodPut, endpoint, pipeline, nil)
+}
+
+// DeleteIngestPipeline deletes ingest pipeline, ErrNotFound is returned if it doesn't exist.
+func (c *Client) DeleteIngestPipeline(ctx context.Context, id string) error {
+	endpoint, err := c.endpoint("es", "_ingest", "pipeline", id)
+	if err != nil {
+		return err
+	}
+
+	err = c.doJSON(ctx, http.MethodDelete, endpoint, nil, nil)
+	if isStatus(err, http.StatusNotFound) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// ingestEndpoint adds WithIngestPipeline pipeline to document creation endpoint.
+func (c *Client) ingestEndpoint(endpoint string) string {
+	if c.ingestPipeline == "" {
+		return endpoint
+	}
+
+	return endpoint + "?" + url.Values{"pipeline": {c.ingestPipeline}}.Encode()
+}
@@ -0,0 +1,88 @@
+package zincmetric
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// IPRangeSpec is a range of IP addresses from From (inclusive) to To (exclusive), either bound
+// can be empty. From can be a CIDR subnet (e.g. "10.0.0.0/8"), To must be empty then.
+type IPRangeSpec struct {
+	From string
+	To   string
+}
+
+// spec returns ip_range aggregation range.
+func (r IPRangeSpec) spec() (map[string]string, error) {
+	if strings.Contains(r.From, "/") {
+		if r.To != "" {
+			return nil, fmt.Errorf("ip range %q has both subnet and upper bound", r.From)
+		}
+		if _, err := netip.ParsePrefix(r.From); err != nil {
+			return nil, err
+		}
+		return map[string]string{"mask": r.From}, nil
+	}
+
+	if r.From == "" && r.To == "" {
+		return nil, errors.New("ip range requires at least one bound")
+	}
+
+	spec := make(map[string]string, 2)
+	for key, ip := range map[string]string{"from": r.From, "to": r.To} {
+		if ip == "" {
+			continue
+		}
+		if _, err := netip.ParseAddr(ip); err != nil {
+			return nil, err
+		}
+		spec[key] = ip
+	}
+
+	return spec, nil
+}
+
+// IPRange groups documents into a bucket per range of field IP addresses.
+// Results decode into IPRangeAggResult.
+func (AggregationBuilder) IPRange(name, field string, ranges []IPRangeSpec) Aggregation {
+	if len(ranges) == 0 {
+		return aggregation{name: name, err: errors.New("ip range aggregation has no ranges")}
+	}
+
+	specs := make([]map[string]string, len(ranges))
+	for i, r := range ranges {
+		spec, err := r.spec()
+		if err != nil {
+			return aggregation{name: name, err: err}
+		}
+		specs[i] = spec
+	}
+
+	return aggregation{name: name, typ: "ip_range", body: map[string]any{"field": field, "ranges": specs}}
+}
+
+// IPRangeAggResult is the result of IPRange aggregation.
+type IPRangeAggResult struct {
+	Buckets []IPRangeBucket `json:"buckets"`
+}
+
+// IPRangeBucket holds documents within a range of IP addresses.
+type IPRangeBucket struct {
+	Key string
+	// From and To are empty if the range is unbounded.
+	From     string
+	To       string
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (b *IPRangeBucket) UnmarshalJSON(data []byte) error {
+	var err error
+	b.Aggregations, err = splitBucket(data, map[string]any{
+		"key": &b.Key, "from": &b.From, "to": &b.To, "doc_count": &b.DocCount,
+	})
+	return err
+}
@@ -0,0 +1,91 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// DocumentIterator streams search results one document at a time,
+// fetching pages transparently as needed.
+type DocumentIterator struct {
+	ctx      context.Context
+	scroller *Scroller
+
+	page  []SearchHit
+	total int64
+}
+
+// SearchIterator creates iterator going through all documents matching query.
+// Iterator should be closed to release the search context.
+func (c *Client) SearchIterator(ctx context.Context, query json.RawMessage) *DocumentIterator {
+	return &DocumentIterator{
+		ctx:      ctx,
+		scroller: c.Scroll(query, defaultScrollKeepAlive),
+	}
+}
+
+// Next returns next document source, io.EOF is returned once all documents were read.
+func (it *DocumentIterator) Next() (json.RawMessage, error) {
+	for len(it.page) == 0 {
+		res, err := it.scroller.Next(it.ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		it.page = res.Hits
+		it.total = res.Total
+	}
+
+	hit := it.page[0]
+	it.page = it.page[1:]
+
+	return hit.Source, nil
+}
+
+// Count returns estimated total number of matched documents, known after the first Next call.
+func (it *DocumentIterator) Count() int64 {
+	return it.total
+}
+
+// Close releases search context.
+func (it *DocumentIterator) Close() error {
+	return it.scroller.Close(context.WithoutCancel(it.ctx))
+}
+
+// StreamSearch sends sources of all documents matching query to the returned channel, paging
+// through results using SearchIterator, as ZincSearch can't stream search responses. Error channel
+// receives a single error if streaming fails, both channels are closed once streaming ends.
+func (c *Client) StreamSearch(ctx context.Context, query json.RawMessage) (<-chan json.RawMessage, <-chan error) {
+	docs := make(chan json.RawMessage)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(docs)
+
+		it := c.SearchIterator(ctx, query)
+		defer it.Close()
+
+		for {
+			doc, err := it.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case docs <- doc:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return docs, errs
+}
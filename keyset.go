@@ -0,0 +1,81 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+// hitsSize sets number of hits returned, non positive size leaves query size unchanged.
+func hitsSize(size int) SearchOption {
+	return func(r *searchRequest) error {
+		if size <= 0 {
+			return nil
+		}
+		return r.set("size", size)
+	}
+}
+
+// noHits makes search return no hits, e.g. when only aggregations are needed.
+func noHits(r *searchRequest) error {
+	return r.set("size", 0)
+}
+
+// SearchAfter runs query returning size hits following the hit with sortValues,
+// see SearchResult.LastSortValues. Query must be sorted, empty sortValues start from the beginning.
+func (c *Client) SearchAfter(ctx context.Context, query json.RawMessage, sortValues []json.RawMessage, size int) (*SearchResult, error) {
+	return c.Search(ctx, query, searchAfter(sortValues), hitsSize(size))
+}
+
+// LastSortValues returns sort values of the last hit, to be passed to SearchAfter for the next page.
+func (r *SearchResult) LastSortValues() []json.RawMessage {
+	if len(r.Hits) == 0 {
+		return nil
+	}
+
+	return r.Hits[len(r.Hits)-1].Sort
+}
+
+// KeysetPaginator pages through sorted search results using search_after.
+// Unlike Scroller, it keeps no search context and pages reflect index changes.
+type KeysetPaginator struct {
+	c     *Client
+	query json.RawMessage
+	size  int
+
+	after []json.RawMessage
+	done  bool
+}
+
+// KeysetPaginate creates a paginator going through results of the sorted query, size hits per page.
+func (c *Client) KeysetPaginate(query json.RawMessage, size int) *KeysetPaginator {
+	return &KeysetPaginator{c: c, query: query, size: size}
+}
+
+// Next returns next page of results, io.EOF is returned once all results were read.
+func (p *KeysetPaginator) Next(ctx context.Context) (*SearchResult, error) {
+	if p.done {
+		return nil, io.EOF
+	}
+
+	res, err := p.c.SearchAfter(ctx, p.query, p.after, p.size)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Hits) == 0 {
+		p.done = true
+		return nil, io.EOF
+	}
+	p.after = res.LastSortValues()
+	// Hits without sort values can't be continued after, which would fetch the first page again.
+	p.done = p.after == nil
+
+	return res, nil
+}
+
+// SortValues returns sort values pagination continues after, they can be stored
+// to resume pagination later using SearchAfter.
+func (p *KeysetPaginator) SortValues() []json.RawMessage {
+	return p.after
+}
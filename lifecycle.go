@@ -0,0 +1,49 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// CreateLifecyclePolicy creates or replaces index lifecycle policy, e.g.
+// {"policy": {"phases": {"delete": {"min_age": "30d", "actions": {"delete": {}}}}}}.
+func (c *Client) CreateLifecyclePolicy(ctx context.Context, name string, policy json.RawMessage) error {
+	endpoint, err := c.endpoint("es", "_ilm", "policy", name)
+	if err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodPut, endpoint, policy, nil)
+}
+
+// GetLifecyclePolicy returns index lifecycle policy, ErrNotFound if it doesn't exist.
+func (c *Client) GetLifecyclePolicy(ctx context.Context, name string) (json.RawMessage, error) {
+	endpoint, err := c.endpoint("es", "_ilm", "policy", name)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp map[string]struct {
+		Policy json.RawMessage `json:"policy"`
+	}
+	err = c.doJSON(ctx, http.MethodGet, endpoint, nil, &resp)
+	if isStatus(err, http.StatusNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := resp[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return p.Policy, nil
+}
+
+// AttachLifecyclePolicy makes client's index managed by the lifecycle policy.
+func (c *Client) AttachLifecyclePolicy(ctx context.Context, policyName string) error {
+	return c.updateIndexSetting(ctx, "lifecycle.name", policyName)
+}
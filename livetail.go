@@ -0,0 +1,121 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// liveTailPageSize is the number of new documents read per request by LiveTail.
+const liveTailPageSize = 100
+
+// LiveTail polls client's index every interval for documents matching the filter query (nil matches
+// all) created since the tail started and sends their sources to the returned channel, oldest first.
+// Documents are found by their _created_at field, so WithVersionTracking must be set. Failed polls
+// are logged and retried. Returned channel is closed once ctx is cancelled.
+func (c *Client) LiveTail(ctx context.Context, filter json.RawMessage, interval time.Duration) <-chan json.RawMessage {
+	docs := make(chan json.RawMessage)
+
+	go func() {
+		defer close(docs)
+
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
+
+		t := &liveTail{
+			c:        c,
+			filter:   filter,
+			docs:     docs,
+			lastSeen: time.Now().UTC().Format(time.RFC3339),
+			seen:     make(map[string]bool),
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick.C:
+			}
+
+			if err := t.poll(ctx); err != nil && ctx.Err() == nil {
+				c.logger.Error("failed to poll new documents", logKeyIndex, c.currentIndex(), logKeyError, err)
+			}
+		}
+	}()
+
+	return docs
+}
+
+// liveTail tracks documents already sent by LiveTail.
+type liveTail struct {
+	c      *Client
+	filter json.RawMessage
+	docs   chan<- json.RawMessage
+
+	lastSeen string
+	// seen holds IDs of documents sent with lastSeen creation time, as following polls match them
+	// again: documents created within the same second may be indexed after the poll.
+	seen map[string]bool
+}
+
+// poll sends documents created at or after lastSeen.
+func (t *liveTail) poll(ctx context.Context) error {
+	var qb QueryBuilder
+	q := qb.Bool().Filter(qb.DateRange(createdAtField, t.lastSeen, "", "", ""))
+	if t.filter != nil {
+		q.Filter(rawQuery(t.filter))
+	}
+
+	query, err := json.Marshal(map[string]any{
+		"query": queryJSON{q},
+		// _id breaks ties of documents created within the same second, so pages don't skip them.
+		"sort": []map[string]string{{createdAtField: "asc"}, {"_id": "asc"}},
+	})
+	if err != nil {
+		return err
+	}
+
+	pages := t.c.KeysetPaginate(query, liveTailPageSize)
+	for {
+		res, err := pages.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range res.Hits {
+			if err := t.send(ctx, hit); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// send sends the document unless it was already sent.
+func (t *liveTail) send(ctx context.Context, hit SearchHit) error {
+	var doc struct {
+		CreatedAt string `json:"_created_at"`
+	}
+	if err := json.Unmarshal(hit.Source, &doc); err != nil {
+		return err
+	}
+
+	if doc.CreatedAt == t.lastSeen && t.seen[hit.ID] {
+		return nil
+	}
+	if doc.CreatedAt != t.lastSeen {
+		t.lastSeen = doc.CreatedAt
+		t.seen = make(map[string]bool)
+	}
+	t.seen[hit.ID] = true
+
+	select {
+	case t.docs <- hit.Source:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,70 @@
+package zincmetric
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Attribute keys used in client log messages.
+const (
+	logKeyIndex     = "index"
+	logKeyDocCount  = "doc_count"
+	logKeyBatchSize = "batch_size"
+	logKeyError     = "error"
+)
+
+// Logger is used by the client to report events that can't be
+// returned to the caller, like failed background flushes.
+// Arguments are alternating key/value pairs, same as log/slog.
+// Loggers may also implement DebugEnabled() bool method, letting the client
+// skip building expensive debug messages when debug level is disabled.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// nopLogger discards all log messages, used when no Logger is configured.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+
+// debugEnabler is optionally implemented by Logger.
+type debugEnabler interface {
+	DebugEnabled() bool
+}
+
+// debugEnabled reports whether the logger logs debug messages.
+// Loggers not implementing DebugEnabled method are assumed to.
+func debugEnabled(l Logger) bool {
+	if l == nil {
+		return false
+	}
+	if d, ok := l.(debugEnabler); ok {
+		return d.DebugEnabled()
+	}
+
+	return true
+}
+
+// slogLogger adapts *slog.Logger to Logger interface.
+type slogLogger struct {
+	l *slog.Logger
+}
+
+// NewSlogLogger creates Logger writing to slog logger. Client log messages
+// carry "index", "doc_count", "batch_size" and "error" attributes where relevant.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return slogLogger{l: logger}
+}
+
+func (s slogLogger) Debug(msg string, args ...any) { s.l.Debug(msg, args...) }
+func (s slogLogger) Warn(msg string, args ...any)  { s.l.Warn(msg, args...) }
+func (s slogLogger) Error(msg string, args ...any) { s.l.Error(msg, args...) }
+
+// DebugEnabled reports whether slog logger handles debug level.
+func (s slogLogger) DebugEnabled() bool {
+	return s.l.Enabled(context.Background(), slog.LevelDebug)
+}
@@ -0,0 +1,131 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// MappingConflict is a field whose sample value type doesn't fit its mapped type.
+type MappingConflict struct {
+	Field        string
+	ExistingType string
+	InferredType string
+}
+
+// typeFamilies groups mapping types accepting the same JSON values.
+var typeFamilies = map[string]string{
+	"text": "string", "keyword": "string",
+	"numeric": "number", "integer": "number", "long": "number", "short": "number", "byte": "number",
+	"float": "number", "double": "number", "half_float": "number", "scaled_float": "number",
+	"bool": "bool", "boolean": "bool",
+	"date": "date", "time": "date",
+}
+
+// ValidateMappingCompatibility infers field types of sample documents and returns fields whose
+// types conflict with client's index mapping, e.g. a number sent to a field mapped as text.
+// Nested object fields are checked by their dotted path, unmapped fields don't conflict.
+func (c *Client) ValidateMappingCompatibility(ctx context.Context, sampleDocs []json.RawMessage) ([]MappingConflict, error) {
+	raw, err := c.GetMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]string)
+	if len(raw) > 0 {
+		var mapping mappingProperties
+		if err := json.Unmarshal(raw, &mapping); err != nil {
+			return nil, err
+		}
+		mapping.flatten("", existing)
+	}
+
+	conflicts := make(map[string]MappingConflict)
+	for _, doc := range sampleDocs {
+		var fields map[string]any
+		if err := json.Unmarshal(doc, &fields); err != nil {
+			return nil, err
+		}
+
+		inferred := make(map[string]string)
+		inferTypes("", fields, inferred)
+		for field, typ := range inferred {
+			mapped, ok := existing[field]
+			if _, seen := conflicts[field]; !ok || seen || typesCompatible(mapped, typ) {
+				continue
+			}
+			conflicts[field] = MappingConflict{Field: field, ExistingType: mapped, InferredType: typ}
+		}
+	}
+
+	out := make([]MappingConflict, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		out = append(out, conflict)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Field < out[j].Field })
+
+	return out, nil
+}
+
+// mappingProperties is the properties part of index mapping.
+type mappingProperties struct {
+	Properties map[string]struct {
+		Type string `json:"type"`
+		mappingProperties
+	} `json:"properties"`
+}
+
+// flatten collects field types by dotted path.
+func (m mappingProperties) flatten(prefix string, types map[string]string) {
+	for name, prop := range m.Properties {
+		if prop.Type != "" {
+			types[prefix+name] = prop.Type
+		}
+		prop.mappingProperties.flatten(prefix+name+".", types)
+	}
+}
+
+// inferTypes collects mapping types of document field values by dotted path.
+func inferTypes(prefix string, fields map[string]any, types map[string]string) {
+	for name, v := range fields {
+		if arr, ok := v.([]any); ok && len(arr) > 0 {
+			v = arr[0]
+		}
+
+		switch v := v.(type) {
+		case map[string]any:
+			inferTypes(prefix+name+".", v, types)
+		case string:
+			if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				types[prefix+name] = "date"
+			} else {
+				types[prefix+name] = "text"
+			}
+		case float64:
+			types[prefix+name] = "numeric"
+		case bool:
+			types[prefix+name] = "bool"
+		}
+	}
+}
+
+// typesCompatible reports whether values of inferred type can be indexed as mapped type.
+func typesCompatible(mapped, inferred string) bool {
+	mappedFamily, ok := typeFamilies[mapped]
+	if !ok {
+		return true // unknown mapping types aren't checked
+	}
+
+	inferredFamily := typeFamilies[inferred]
+	switch {
+	case mappedFamily == inferredFamily:
+		return true
+	case mappedFamily == "string" && inferredFamily == "date":
+		return true // dates are strings
+	case mappedFamily == "date" && inferredFamily == "number":
+		return true // epoch timestamps
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+package zincmetric
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"strings"
+)
+
+// MaskFunc masks a sensitive field value.
+type MaskFunc func(value string) string
+
+// MaskingRule masks value of a field, nested fields are specified using dot notation (e.g. "user.email").
+type MaskingRule struct {
+	Field string
+	Mask  MaskFunc
+}
+
+// maskPlaceholder replaces masked values.
+const maskPlaceholder = "***"
+
+// MaskAll replaces the whole value.
+func MaskAll() MaskFunc {
+	return func(string) string {
+		return maskPlaceholder
+	}
+}
+
+// MaskPartial keeps first keep characters of the value.
+func MaskPartial(keep int) MaskFunc {
+	return func(v string) string {
+		r := []rune(v)
+		if keep >= len(r) {
+			return v
+		}
+
+		return string(r[:max(keep, 0)]) + maskPlaceholder
+	}
+}
+
+// MaskEmail replaces local part of email address, keeping the domain.
+func MaskEmail() MaskFunc {
+	return func(v string) string {
+		at := strings.LastIndexByte(v, '@')
+		if at < 0 {
+			return maskPlaceholder
+		}
+
+		return maskPlaceholder + v[at:]
+	}
+}
+
+// MaskPhone keeps only the last 4 digits of phone number.
+func MaskPhone() MaskFunc {
+	return func(v string) string {
+		digits := make([]byte, 0, len(v))
+		for i := 0; i < len(v); i++ {
+			if v[i] >= '0' && v[i] <= '9' {
+				digits = append(digits, v[i])
+			}
+		}
+		if len(digits) <= 4 {
+			return maskPlaceholder
+		}
+
+		return maskPlaceholder + string(digits[len(digits)-4:])
+	}
+}
+
+// MaskIP replaces last octet of IPv4 address (last group of IPv6 address).
+func MaskIP() MaskFunc {
+	return func(v string) string {
+		ip := net.ParseIP(v)
+		switch {
+		case ip == nil:
+			return maskPlaceholder
+		case ip.To4() != nil:
+			return v[:strings.LastIndexByte(v, '.')+1] + "0"
+		default:
+			return v[:strings.LastIndexByte(v, ':')+1] + "0"
+		}
+	}
+}
+
+// maskFields creates TransformFunc applying masking rules to JSON documents.
+func maskFields(rules []MaskingRule) TransformFunc {
+	return func(data []byte) ([]byte, error) {
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+
+		var doc map[string]any
+		if err := d.Decode(&doc); err != nil {
+			return nil, err
+		}
+
+		for _, rule := range rules {
+			maskField(doc, strings.Split(rule.Field, "."), rule.Mask)
+		}
+
+		return json.Marshal(doc)
+	}
+}
+
+// maskField walks path in the document and masks the value found.
+func maskField(doc map[string]any, path []string, mask MaskFunc) {
+	v, ok := doc[path[0]]
+	if !ok {
+		return
+	}
+
+	if len(path) > 1 {
+		if nested, ok := v.(map[string]any); ok {
+			maskField(nested, path[1:], mask)
+		}
+		return
+	}
+
+	switch v := v.(type) {
+	case string:
+		doc[path[0]] = mask(v)
+	case json.Number:
+		doc[path[0]] = mask(v.String())
+	}
+}
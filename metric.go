@@ -0,0 +1,188 @@
+package zincmetric
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Avg computes average of numeric field values, result is MetricResult.
+func (AggregationBuilder) Avg(name, field string) Aggregation {
+	return fieldAggregation(name, "avg", field)
+}
+
+// Sum computes sum of numeric field values, result is MetricResult.
+func (AggregationBuilder) Sum(name, field string) Aggregation {
+	return fieldAggregation(name, "sum", field)
+}
+
+// Min computes minimum of numeric field values, result is MetricResult.
+func (AggregationBuilder) Min(name, field string) Aggregation {
+	return fieldAggregation(name, "min", field)
+}
+
+// Max computes maximum of numeric field values, result is MetricResult.
+func (AggregationBuilder) Max(name, field string) Aggregation {
+	return fieldAggregation(name, "max", field)
+}
+
+// fieldAggregation is aggregation of typ having only the field set.
+func fieldAggregation(name, typ, field string) Aggregation {
+	return aggregation{name: name, typ: typ, body: map[string]string{"field": field}}
+}
+
+// MetricResult is the result of single value metric or pipeline aggregation.
+type MetricResult struct {
+	// Value is nil if there were no values to aggregate.
+	Value *float64 `json:"value"`
+}
+
+// ExtendedStats computes statistics of numeric field values, std deviation bounds are
+// sigma standard deviations from the average (0 uses the server default of 2).
+func (AggregationBuilder) ExtendedStats(name, field string, sigma float64) Aggregation {
+	body := map[string]any{"field": field}
+	if sigma > 0 {
+		body["sigma"] = sigma
+	}
+
+	return aggregation{name: name, typ: "extended_stats", body: body}
+}
+
+// ExtendedStatsResult is the result of ExtendedStats aggregation, values are 0 if Count is 0.
+type ExtendedStatsResult struct {
+	Count        int64        `json:"count"`
+	Min          float64      `json:"min"`
+	Max          float64      `json:"max"`
+	Avg          float64      `json:"avg"`
+	Sum          float64      `json:"sum"`
+	SumOfSquares float64      `json:"sum_of_squares"`
+	Variance     float64      `json:"variance"`
+	StdDev       float64      `json:"std_deviation"`
+	StdDevBounds StdDevBounds `json:"std_deviation_bounds"`
+}
+
+// StdDevBounds are values sigma standard deviations above and below the average.
+type StdDevBounds struct {
+	Upper float64 `json:"upper"`
+	Lower float64 `json:"lower"`
+}
+
+// Cardinality approximately counts distinct field values using HyperLogLog++. Counts below
+// precisionThreshold (up to 40000, 0 uses the server default of 3000) are close to exact,
+// higher counts have around 1-6% error; memory used grows with the threshold
+// (about 8 bytes per unit).
+func (AggregationBuilder) Cardinality(name, field string, precisionThreshold int) Aggregation {
+	body := map[string]any{"field": field}
+	if precisionThreshold > 0 {
+		body["precision_threshold"] = precisionThreshold
+	}
+
+	return aggregation{name: name, typ: "cardinality", body: body}
+}
+
+// CardinalityResult is the result of Cardinality aggregation.
+type CardinalityResult struct {
+	Value int64 `json:"value"`
+}
+
+// ScriptedMetric computes metric using Painless scripts, each being a JSON script source string or
+// script object (e.g. {"source": "...", "lang": "painless"}), only mapScript is required.
+// Scripts run on every matched document, so they're much slower than built in aggregations;
+// compiled scripts are cached by their source, so values changing between requests should be
+// passed in params instead of being embedded in scripts.
+func (AggregationBuilder) ScriptedMetric(name string, initScript, mapScript, combineScript, reduceScript json.RawMessage, params map[string]any) Aggregation {
+	if len(mapScript) == 0 {
+		return aggregation{name: name, err: errors.New("scripted metric aggregation has no map script")}
+	}
+
+	body := map[string]any{"map_script": mapScript}
+	for key, script := range map[string]json.RawMessage{
+		"init_script":    initScript,
+		"combine_script": combineScript,
+		"reduce_script":  reduceScript,
+	} {
+		if len(script) > 0 {
+			body[key] = script
+		}
+	}
+	if len(params) > 0 {
+		body["params"] = params
+	}
+
+	return aggregation{name: name, typ: "scripted_metric", body: body}
+}
+
+// ScriptedMetricResult is the result of ScriptedMetric aggregation.
+type ScriptedMetricResult struct {
+	// Value is the value returned by reduce script.
+	Value json.RawMessage `json:"value"`
+}
+
+// Percentiles approximately computes percents percentiles (e.g. 50, 95, 99) of numeric field values.
+// Higher compression (0 uses the server default of 100) improves accuracy at the cost of memory.
+// Results decode into PercentilesResult.
+func (AggregationBuilder) Percentiles(name, field string, percents []float64, compression float64) Aggregation {
+	body := map[string]any{"field": field}
+	if len(percents) > 0 {
+		body["percents"] = percents
+	}
+	if compression > 0 {
+		body["tdigest"] = map[string]float64{"compression": compression}
+	}
+
+	return aggregation{name: name, typ: "percentiles", body: body}
+}
+
+// PercentileRanks approximately computes percentage of numeric field values at or below each of values.
+// Results decode into PercentilesResult.
+func (AggregationBuilder) PercentileRanks(name, field string, values []float64) Aggregation {
+	if len(values) == 0 {
+		return aggregation{name: name, err: errors.New("percentile ranks aggregation has no values")}
+	}
+
+	return aggregation{name: name, typ: "percentile_ranks", body: map[string]any{"field": field, "values": values}}
+}
+
+// PercentilesResult is the result of Percentiles or PercentileRanks aggregation.
+type PercentilesResult struct {
+	// Values maps percents to percentiles, or values to their percentile ranks.
+	// It's empty if there were no values to aggregate.
+	Values map[float64]float64
+}
+
+func (r *PercentilesResult) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Values map[string]*float64 `json:"values"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	r.Values = make(map[float64]float64, len(wire.Values))
+	for k, v := range wire.Values {
+		key, err := strconv.ParseFloat(k, 64)
+		if err != nil {
+			return fmt.Errorf("percentile key %q: %w", k, err)
+		}
+		if v != nil {
+			r.Values[key] = *v
+		}
+	}
+
+	return nil
+}
+
+// Stats computes count, min, max, avg and sum of numeric field values, results decode into StatsResult.
+func (AggregationBuilder) Stats(name, field string) Aggregation {
+	return fieldAggregation(name, "stats", field)
+}
+
+// StatsResult is the result of Stats aggregation, values are nil if Count is 0.
+type StatsResult struct {
+	Count int64    `json:"count"`
+	Min   *float64 `json:"min"`
+	Max   *float64 `json:"max"`
+	Avg   *float64 `json:"avg"`
+	Sum   float64  `json:"sum"`
+}
@@ -0,0 +1,101 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// defaultMaxBulkSize is the number of documents requested at once by GetDocuments,
+// unless set using WithMaxBulkSize.
+const defaultMaxBulkSize = 1000
+
+// DocumentErrors is returned by GetDocuments when some of the documents failed to be retrieved,
+// it maps document ID to its error.
+type DocumentErrors map[string]error
+
+func (e DocumentErrors) Error() string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	msgs := make([]string, len(ids))
+	for i, id := range ids {
+		msgs[i] = fmt.Sprintf("%s: %s", id, e[id])
+	}
+
+	return fmt.Sprintf("failed to get %d documents: %s", len(e), strings.Join(msgs, "; "))
+}
+
+type mgetDoc struct {
+	Index string `json:"_index"`
+	ID    string `json:"_id"`
+}
+
+// mgetResponse is the multi-get API response.
+type mgetResponse struct {
+	Docs []struct {
+		ID     string          `json:"_id"`
+		Found  bool            `json:"found"`
+		Source json.RawMessage `json:"_source"`
+		Error  json.RawMessage `json:"error"`
+	} `json:"docs"`
+}
+
+// GetDocuments returns sources of the documents with the given IDs, mapped by ID.
+// Documents that don't exist are absent from the result. Documents that failed to be
+// retrieved are reported with DocumentErrors, together with all retrieved documents.
+func (c *Client) GetDocuments(ctx context.Context, ids []string) (map[string]json.RawMessage, error) {
+	endpoint, err := c.endpoint("api", "_mget")
+	if err != nil {
+		return nil, err
+	}
+
+	size := c.maxBulkSize
+	if size <= 0 {
+		size = defaultMaxBulkSize
+	}
+
+	index := c.currentIndex()
+	docs := make(map[string]json.RawMessage, len(ids))
+	var docErrs DocumentErrors
+	for len(ids) > 0 {
+		n := min(len(ids), size)
+
+		req := make([]mgetDoc, n)
+		for i, id := range ids[:n] {
+			req[i] = mgetDoc{Index: index, ID: id}
+		}
+
+		var resp mgetResponse
+		err := c.doJSON(ctx, http.MethodPost, c.readEndpoint(endpoint), map[string][]mgetDoc{"docs": req}, &resp)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range resp.Docs {
+			switch {
+			case len(doc.Error) > 0 && string(doc.Error) != "null":
+				if docErrs == nil {
+					docErrs = make(DocumentErrors)
+				}
+				docErrs[doc.ID] = fmt.Errorf("%s", doc.Error)
+			case doc.Found:
+				docs[doc.ID] = doc.Source
+			}
+		}
+
+		ids = ids[n:]
+	}
+
+	if docErrs != nil {
+		return docs, docErrs
+	}
+
+	return docs, nil
+}
@@ -0,0 +1,218 @@
+package zincmetric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Migrate moves client's index to a new mapping. A new index is created with newMapping,
+// all documents are copied to it in batches of batchSize, client's index name is then
+// atomically switched to be an alias of the new index and the old index is deleted.
+//
+// Progress is reported using WithOnFlush callback after every copied batch. If migration
+// fails or ctx is cancelled before the switch, the new index is deleted and the old one is
+// left untouched. Documents written to the client while migrating may not be carried over.
+func (c *Client) Migrate(ctx context.Context, newMapping json.RawMessage, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = defaultScanSize
+	}
+
+	oldIndexes, err := c.aliasIndexes(ctx, c.currentIndex())
+	if err != nil {
+		return err
+	}
+	if len(oldIndexes) == 0 {
+		// Index isn't an alias yet, it will be replaced by one.
+		oldIndexes = []string{c.currentIndex()}
+	}
+
+	newIndex := fmt.Sprintf("%s-%d", c.currentIndex(), time.Now().UnixNano())
+	if err := c.CreateIndex(ctx, newIndex, newMapping); err != nil {
+		return err
+	}
+
+	if err := c.copyDocuments(ctx, newIndex, batchSize); err != nil {
+		return c.dropMigrationIndex(ctx, newIndex, err)
+	}
+
+	actions := []aliasAction{{"add": {"index": newIndex, "alias": c.currentIndex()}}}
+	for _, index := range oldIndexes {
+		actions = append(actions, aliasAction{"remove_index": {"index": index}})
+	}
+
+	if err := c.updateAliases(ctx, actions...); err != nil {
+		return c.dropMigrationIndex(ctx, newIndex, err)
+	}
+
+	return nil
+}
+
+// dropMigrationIndex deletes index created by failed migration, returning err joined with deletion error.
+func (c *Client) dropMigrationIndex(ctx context.Context, index string, err error) error {
+	if derr := c.DeleteIndex(context.WithoutCancel(ctx), index); derr != nil {
+		return errors.Join(err, derr)
+	}
+
+	return err
+}
+
+// copyDocuments copies all client's index documents to the target index, keeping document IDs.
+func (c *Client) copyDocuments(ctx context.Context, target string, batchSize int) error {
+	batch := make([][]byte, 0, batchSize)
+	push := func() error {
+		start := time.Now()
+		if err := c.bulkInsert(ctx, target, batch); err != nil {
+			return err
+		}
+
+		c.notifyFlush(FlushInfo{Index: target, Documents: len(batch), Duration: time.Since(start)})
+		batch = batch[:0]
+		return nil
+	}
+
+	err := c.scan(ctx, batchSize, func(hit SearchHit) error {
+		doc, err := withDocumentID(hit.Source, hit.ID)
+		if err != nil {
+			return err
+		}
+
+		batch = append(batch, doc)
+		if len(batch) < batchSize {
+			return nil
+		}
+		return push()
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(batch) > 0 {
+		if err := push(); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// ReIndexWithTransform copies all documents of src index to client's index, transforming each
+// with transform and keeping document IDs. Documents transformed to nil are skipped. Documents are
+// written using WriteBatch in batches of batchSize, so progress can be followed using WithOnFlush.
+// Returns the number of documents written.
+func (c *Client) ReIndexWithTransform(ctx context.Context, src *Client, transform func(json.RawMessage) (json.RawMessage, error), batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = defaultScanSize
+	}
+
+	var written int64
+	batch := make([][]byte, 0, batchSize)
+	push := func() error {
+		if err := c.WriteBatch(batch); err != nil {
+			return err
+		}
+
+		written += int64(len(batch))
+		batch = batch[:0]
+		return nil
+	}
+
+	err := src.scan(ctx, batchSize, func(hit SearchHit) error {
+		doc, err := transform(hit.Source)
+		if err != nil {
+			return fmt.Errorf("transform document %q: %w", hit.ID, err)
+		}
+		if doc == nil {
+			return nil
+		}
+
+		if doc, err = withDocumentID(doc, hit.ID); err != nil {
+			return err
+		}
+
+		batch = append(batch, doc)
+		if len(batch) < batchSize {
+			return nil
+		}
+		return push()
+	})
+	if err != nil {
+		return written, err
+	}
+
+	if len(batch) > 0 {
+		if err := push(); err != nil {
+			return written, err
+		}
+	}
+
+	return written, ctx.Err()
+}
+
+// withDocumentID adds "_id" key to JSON object document, so bulk inserts keep the document ID.
+func withDocumentID(doc json.RawMessage, id string) (json.RawMessage, error) {
+	if id == "" {
+		return doc, nil
+	}
+
+	doc = bytes.TrimSpace(doc)
+	if len(doc) < 2 || doc[0] != '{' {
+		return nil, fmt.Errorf("document %q is not a JSON object", id)
+	}
+
+	key, err := json.Marshal(id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(doc)+len(key)+8)
+	out = append(out, `{"_id":`...)
+	out = append(out, key...)
+	if rest := bytes.TrimSpace(doc[1:]); len(rest) > 0 && rest[0] != '}' {
+		out = append(out, ',')
+	}
+
+	return append(out, doc[1:]...), nil
+}
+
+// SwapIndex copies all documents of client's index to newClient's index and then atomically
+// points client's index name, used as an alias, to the new index. Documents buffered by the
+// client are pushed to the old index before copying, so they're carried over, writes that
+// are pushed after the swap go to the new index.
+//
+// If client's index name is an alias, it's removed from the indexes it pointed to, which are
+// kept. Otherwise the index is deleted, so its name can become an alias, like Migrate does.
+// Documents written to the client while copying may not be carried over.
+func (c *Client) SwapIndex(ctx context.Context, newClient *Client) error {
+	alias, newIndex := c.currentIndex(), newClient.currentIndex()
+	if alias == newIndex {
+		return fmt.Errorf("swap index: new index %q is the client's index", newIndex)
+	}
+
+	oldIndexes, err := c.aliasIndexes(ctx, alias)
+	if err != nil {
+		return err
+	}
+
+	if err := c.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+	if err := c.copyDocuments(ctx, newIndex, defaultScanSize); err != nil {
+		return fmt.Errorf("copy documents: %w", err)
+	}
+
+	actions := []aliasAction{{"add": {"index": newIndex, "alias": alias}}}
+	if len(oldIndexes) == 0 {
+		actions = append(actions, aliasAction{"remove_index": {"index": alias}})
+	}
+	for _, index := range oldIndexes {
+		if index != newIndex {
+			actions = append(actions, aliasAction{"remove": {"index": index, "alias": alias}})
+		}
+	}
+
+	return c.updateAliases(ctx, actions...)
+}
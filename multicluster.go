@@ -0,0 +1,94 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// MultiClusterClient replicates writes to multiple ZincSearch clusters, one client per cluster.
+type MultiClusterClient struct {
+	clusters []*Client
+}
+
+// NewMultiClusterClient creates MultiClusterClient over clients of the given clusters.
+func NewMultiClusterClient(clusters []*Client) *MultiClusterClient {
+	return &MultiClusterClient{clusters: clusters}
+}
+
+// Write writes document to all clusters in parallel, waiting for all of them to accept it.
+func (m *MultiClusterClient) Write(data []byte) (int, error) {
+	if err := m.each(func(c *Client) error {
+		_, err := c.Write(data)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// WriteBatch writes documents to all clusters in parallel, see Client.WriteBatch.
+func (m *MultiClusterClient) WriteBatch(docs [][]byte) error {
+	return m.each(func(c *Client) error {
+		return c.WriteBatch(docs)
+	})
+}
+
+// Search runs the query on the first ready cluster, see Client.IsReady.
+func (m *MultiClusterClient) Search(ctx context.Context, query json.RawMessage, opts ...SearchOption) (*SearchResult, error) {
+	for _, c := range m.clusters {
+		if c.isReady(ctx) {
+			return c.Search(ctx, query, opts...)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, errors.New("no ready clusters")
+}
+
+// Stats returns statistics summed across all clusters, CurrentBatchSize is the largest one.
+func (m *MultiClusterClient) Stats() Stats {
+	var total Stats
+	for _, c := range m.clusters {
+		s := c.Stats()
+		total.DocumentsSent += s.DocumentsSent
+		total.FlushErrors += s.FlushErrors
+		total.BufferDepth += s.BufferDepth
+		total.DocumentsShed += s.DocumentsShed
+		total.CurrentBatchSize = max(total.CurrentBatchSize, s.CurrentBatchSize)
+	}
+
+	return total
+}
+
+// Close gracefully closes all clusters in parallel, see Client.CloseGracefully.
+func (m *MultiClusterClient) Close() error {
+	return m.each(func(c *Client) error {
+		return c.CloseGracefully(context.Background())
+	})
+}
+
+// each calls fn for every cluster in parallel and joins the errors.
+func (m *MultiClusterClient) each(fn func(c *Client) error) error {
+	errs := make([]error, len(m.clusters))
+
+	var wg sync.WaitGroup
+	for i, c := range m.clusters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(c); err != nil {
+				errs[i] = fmt.Errorf("cluster %d: %w", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -1,6 +1,8 @@
 package zincmetric
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"time"
 )
@@ -13,8 +15,356 @@ func WithHttpClient(h *http.Client) OptionFunc {
 	}
 }
 
+// WithConnectionPool makes client share HTTP connections with other clients using the same pool.
+func WithConnectionPool(pool *ConnectionPool) OptionFunc {
+	return func(c *Client) {
+		c.pool = pool
+	}
+}
+
 func WithFlushInterval(d time.Duration) OptionFunc {
 	return func(c *Client) {
 		c.flushInterval = d
 	}
 }
+
+// WithLogger sets logger used to report background errors and warnings.
+func WithLogger(l Logger) OptionFunc {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithWriteTimeout limits how long Write waits for the document to be accepted.
+func WithWriteTimeout(d time.Duration) OptionFunc {
+	return func(c *Client) {
+		c.writeTimeout = d
+	}
+}
+
+// WithSlowWriteThreshold logs a warning when Write is blocked for longer than d.
+func WithSlowWriteThreshold(d time.Duration) OptionFunc {
+	return func(c *Client) {
+		c.slowWriteThreshold = d
+	}
+}
+
+// WithSizeTracking enables document size statistics, see Client.SizeStats.
+// Percentiles default to p50, p90 and p99 when none are given.
+func WithSizeTracking(percentiles ...float64) OptionFunc {
+	return func(c *Client) {
+		c.sizeTracking = true
+		if len(percentiles) == 0 {
+			percentiles = defaultSizePercentiles
+		}
+		c.sizePercentiles = percentiles
+	}
+}
+
+// WithAutoTuneBatchSize adjusts number of documents pushed per request,
+// between minSize and maxSize, to keep request latency near targetLatency.
+// New fails unless targetLatency is positive and minSize doesn't exceed maxSize.
+func WithAutoTuneBatchSize(targetLatency time.Duration, minSize, maxSize int) OptionFunc {
+	return func(c *Client) {
+		c.autoTune = &batchTuner{
+			targetLatency: targetLatency,
+			minSize:       max(minSize, 1),
+			maxSize:       maxSize,
+		}
+	}
+}
+
+// WithAutoTuneGain sets proportional gain used by WithAutoTuneBatchSize (default: 0.5).
+func WithAutoTuneGain(gain float64) OptionFunc {
+	return func(c *Client) {
+		c.autoTuneGain = gain
+	}
+}
+
+// WithMaxHealthPollInterval caps WaitForIndexGreen poll interval backoff (default: 5s).
+func WithMaxHealthPollInterval(d time.Duration) OptionFunc {
+	return func(c *Client) {
+		c.maxHealthPollInterval = d
+	}
+}
+
+// WithOnError sets callback called with documents dropped without being pushed to ZincSearch service.
+// Callback is called from the pusher thread, so it should return quickly.
+func WithOnError(fn func(docs [][]byte, err error)) OptionFunc {
+	return func(c *Client) {
+		c.onError = fn
+	}
+}
+
+// WithOnFlush sets callback called after every batch of documents pushed to ZincSearch service.
+// Callback is called from the pusher thread, so it should return quickly.
+func WithOnFlush(fn func(info FlushInfo)) OptionFunc {
+	return func(c *Client) {
+		c.onFlush = fn
+	}
+}
+
+// WithFieldMasker masks sensitive fields of every document before it's written.
+func WithFieldMasker(rules ...MaskingRule) OptionFunc {
+	return func(c *Client) {
+		c.transforms = append(c.transforms, maskFields(rules))
+	}
+}
+
+// WithFieldRemap renames document keys according to mapping before it's written, see RemapFields.
+func WithFieldRemap(mapping map[string]string) OptionFunc {
+	return func(c *Client) {
+		c.transforms = append(c.transforms, RemapFields(mapping))
+	}
+}
+
+// WithQueryCache caches up to maxEntries Search results for ttl.
+func WithQueryCache(ttl time.Duration, maxEntries int) OptionFunc {
+	return func(c *Client) {
+		c.queryCache = newQueryCache(ttl, maxEntries)
+	}
+}
+
+// WithRequestLogging logs every outgoing request with up to maxBytes of its body at debug level.
+func WithRequestLogging(logger Logger, maxBytes int) OptionFunc {
+	return func(c *Client) {
+		c.requestLogger = logger
+		c.requestLogMaxBytes = max(maxBytes, 0)
+	}
+}
+
+// WithContentType sets Content-Type header sent with all requests (default: application/json).
+func WithContentType(ct string) OptionFunc {
+	return func(c *Client) {
+		c.contentType = ct
+	}
+}
+
+// WithAutoRefreshAfterFlush refreshes index after every pushed batch, making documents searchable immediately.
+func WithAutoRefreshAfterFlush() OptionFunc {
+	return func(c *Client) {
+		c.autoRefresh = true
+	}
+}
+
+// WithMaxConflicts sets how many times by-query operations and Merge are retried on version conflict (default: 3).
+func WithMaxConflicts(n int) OptionFunc {
+	return func(c *Client) {
+		c.maxConflicts = max(n, 0)
+	}
+}
+
+// WithCountCacheTTL caches Count results for ttl.
+func WithCountCacheTTL(ttl time.Duration) OptionFunc {
+	return func(c *Client) {
+		c.countCache = newCountCache(ttl)
+	}
+}
+
+// WithPreferLocalNode routes read requests (GetDocument, Search, Count) to the local node when possible.
+func WithPreferLocalNode() OptionFunc {
+	return func(c *Client) {
+		c.preferLocal = true
+	}
+}
+
+// WithIndexWarming runs warmup queries against every index created using CreateIndex.
+func WithIndexWarming(queries []json.RawMessage) OptionFunc {
+	return func(c *Client) {
+		c.warmupQueries = queries
+	}
+}
+
+// WithContextLogger extracts logger from WriteContext context, it's used to report
+// errors of batches containing the document instead of the client logger.
+func WithContextLogger(extract func(ctx context.Context) Logger) OptionFunc {
+	return func(c *Client) {
+		c.contextLogger = extract
+	}
+}
+
+// WithMaxBufferSize limits the number of buffered documents. Full buffer is flushed
+// immediately and writes wait until there's room in the buffer again.
+func WithMaxBufferSize(n int) OptionFunc {
+	return func(c *Client) {
+		c.maxBufferSize = max(n, 0)
+	}
+}
+
+// WithLoadShedding makes Write return ErrLoadShed without waiting once buffer utilization
+// (see WithMaxBufferSize) goes above maxBufferUtilization, which is clamped to [0.5, 1.0].
+func WithLoadShedding(maxBufferUtilization float64) OptionFunc {
+	return func(c *Client) {
+		c.loadShedding = min(max(maxBufferUtilization, 0.5), 1.0)
+	}
+}
+
+// WithAuditLog appends every written document to the file at path, as "<timestamp>\t<document>" lines.
+func WithAuditLog(path string) OptionFunc {
+	return func(c *Client) {
+		c.auditPath = path
+	}
+}
+
+// WithAuditSyncInterval sets how often audit log is synced to disk (default: 1s).
+func WithAuditSyncInterval(d time.Duration) OptionFunc {
+	return func(c *Client) {
+		c.auditSyncInterval = d
+	}
+}
+
+// WithPersistentDeduplication drops documents identical to ones written within deduplication TTL
+// (see WithDeduplicationTTL). Document hashes are stored in bolt database at dbPath, surviving restarts.
+func WithPersistentDeduplication(dbPath string) OptionFunc {
+	return func(c *Client) {
+		c.dedupPath = dbPath
+	}
+}
+
+// WithDeduplicationTTL sets how long written documents are remembered for deduplication (default: 24h).
+func WithDeduplicationTTL(ttl time.Duration) OptionFunc {
+	return func(c *Client) {
+		c.dedupTTL = ttl
+	}
+}
+
+// WithIndexNameNormalizer normalizes client's index name and names passed to CreateIndex
+// and DeleteIndex, e.g. using LowercaseNormalizer.
+func WithIndexNameNormalizer(fn func(name string) string) OptionFunc {
+	return func(c *Client) {
+		c.indexNormalizer = fn
+	}
+}
+
+// WithConnectionMonitoring logs a warning for every request whose time to first byte
+// exceeds slowThreshold, and a summary of slow connections every minute.
+func WithConnectionMonitoring(slowThreshold time.Duration) OptionFunc {
+	return func(c *Client) {
+		c.slowConnThreshold = slowThreshold
+	}
+}
+
+// WithAcceptEncoding sets Accept-Encoding header (e.g. "gzip") on all requests,
+// gzip encoded responses are decompressed automatically.
+func WithAcceptEncoding(enc string) OptionFunc {
+	return func(c *Client) {
+		c.acceptEncoding = enc
+	}
+}
+
+// WithIdempotentBulk assigns a random UUID _id to every written document before it's buffered,
+// making bulk request retries safe as ZincSearch overwrites documents with the same ID.
+func WithIdempotentBulk() OptionFunc {
+	return func(c *Client) {
+		c.idempotentBulk = true
+	}
+}
+
+// WithMinBatchSize delays periodic flushes until at least n documents are buffered,
+// or the oldest buffered document waited for WithMinBatchMaxWait (default: 10s).
+func WithMinBatchSize(n int) OptionFunc {
+	return func(c *Client) {
+		c.minBatchSize = n
+	}
+}
+
+// WithMinBatchMaxWait limits how long documents wait for WithMinBatchSize batch to fill up.
+func WithMinBatchMaxWait(d time.Duration) OptionFunc {
+	return func(c *Client) {
+		c.minBatchMaxWait = d
+	}
+}
+
+// WithAutoBulkFormat detects bulk format supported by ZincSearch service version in New.
+func WithAutoBulkFormat() OptionFunc {
+	return func(c *Client) {
+		c.autoBulkFormat = true
+	}
+}
+
+// WithMaxBulkSize limits number of documents pushed or requested (see GetDocuments) per request.
+// When WithAutoTuneBatchSize is set, it decides pushed batch size instead.
+func WithMaxBulkSize(n int) OptionFunc {
+	return func(c *Client) {
+		c.maxBulkSize = n
+	}
+}
+
+// WithIndexQuota drops batches that would make index exceed maxDocs documents or maxSizeBytes
+// storage size (0 means no limit), passing them to WithOnError callback with ErrQuotaExceeded.
+func WithIndexQuota(maxDocs int64, maxSizeBytes int64) OptionFunc {
+	return func(c *Client) {
+		c.quota = &indexQuota{maxDocs: maxDocs, maxSize: maxSizeBytes, stats: make(map[string]quotaStats)}
+	}
+}
+
+// WithRateLimitHeaders suspends flushes until X-RateLimit-Reset time once ZincSearch
+// responds with X-RateLimit-Remaining of 0, documents keep being buffered meanwhile.
+func WithRateLimitHeaders() OptionFunc {
+	return func(c *Client) {
+		c.rateLimitHeaders = true
+	}
+}
+
+// WithIngestPipeline makes documents created by the client go through the ingest pipeline.
+func WithIngestPipeline(pipelineID string) OptionFunc {
+	return func(c *Client) {
+		c.ingestPipeline = pipelineID
+	}
+}
+
+// WithSoftDeleteFilter makes Search and Count skip documents deleted using SoftDelete.
+func WithSoftDeleteFilter() OptionFunc {
+	return func(c *Client) {
+		c.softDeleteFilter = true
+	}
+}
+
+// WithFlushVerification refreshes index after every flush and checks its document count grew
+// by the number of flushed documents, passing them to WithOnError callback with
+// ErrVerificationFailed otherwise. See WithVerificationTolerance.
+func WithFlushVerification() OptionFunc {
+	return func(c *Client) {
+		c.flushVerification = true
+	}
+}
+
+// WithVerificationTolerance sets how many flushed documents may be missing from the index
+// (e.g. not yet indexed by ZincSearch) without failing WithFlushVerification.
+func WithVerificationTolerance(n int) OptionFunc {
+	return func(c *Client) {
+		c.verificationTolerance = int64(n)
+	}
+}
+
+// WithVersionTracking sets _created_at and _updated_at fields of written documents not having them,
+// _updated_at is also set on documents written by PutDocument, Update, UpdateWithVersion and Merge,
+// whose versions are also stored in <index>_history index, see GetHistory.
+func WithVersionTracking() OptionFunc {
+	return func(c *Client) {
+		c.versionTracking = true
+	}
+}
+
+// WithMaxQueryCost makes Search reject queries rated over maxRating ("low", "medium", "high"
+// or "very_high") by EstimateQueryCost with ErrQueryTooExpensive.
+func WithMaxQueryCost(maxRating string) OptionFunc {
+	return func(c *Client) {
+		c.maxQueryCost = maxRating
+	}
+}
+
+// WithEnricher sets enricher called with every written document before it's transformed and enqueued,
+// e.g. to add data looked up from external APIs. Documents failing enrichment are written as is.
+func WithEnricher(enrich func(ctx context.Context, doc json.RawMessage) (json.RawMessage, error)) OptionFunc {
+	return func(c *Client) {
+		c.enricher = enrich
+	}
+}
+
+// WithEnricherTimeout limits how long WithEnricher enricher may take per document.
+func WithEnricherTimeout(d time.Duration) OptionFunc {
+	return func(c *Client) {
+		c.enricherTimeout = d
+	}
+}
@@ -0,0 +1,82 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Page is a page of search results unmarshaled into T.
+type Page[T any] struct {
+	Items    []T
+	Total    int64
+	Page     int // 1 based page number
+	PageSize int
+	HasNext  bool
+	HasPrev  bool
+
+	c        *Client
+	query    json.RawMessage
+	lastSort []json.RawMessage
+}
+
+// hitsFrom skips the first from hits.
+func hitsFrom(from int) SearchOption {
+	return func(r *searchRequest) error {
+		if from <= 0 {
+			return nil
+		}
+		return r.set("from", from)
+	}
+}
+
+// SearchPage runs query returning the given page (starting at 1) of size hits unmarshaled into T.
+// Following pages should be requested using Page.NextPage.
+func SearchPage[T any](ctx context.Context, client *Client, query json.RawMessage, page, size int) (*Page[T], error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid page size: %d", size)
+	}
+
+	page = max(page, 1)
+	return searchPage[T](ctx, client, query, page, size, hitsFrom((page-1)*size))
+}
+
+// NextPage returns the following page, io.EOF is returned if there is none. Sorted queries
+// continue after sort values of the last hit (keyset pagination), others use page offset.
+func (p *Page[T]) NextPage(ctx context.Context) (*Page[T], error) {
+	if !p.HasNext {
+		return nil, io.EOF
+	}
+
+	opt := hitsFrom(p.Page * p.PageSize)
+	if p.lastSort != nil {
+		opt = searchAfter(p.lastSort)
+	}
+
+	return searchPage[T](ctx, p.c, p.query, p.Page+1, p.PageSize, opt)
+}
+
+func searchPage[T any](ctx context.Context, client *Client, query json.RawMessage, page, size int, opt SearchOption) (*Page[T], error) {
+	res, err := client.Search(ctx, query, opt, hitsSize(size))
+	if err != nil {
+		return nil, err
+	}
+
+	var items []T
+	if err := res.UnmarshalAll(&items); err != nil {
+		return nil, err
+	}
+
+	return &Page[T]{
+		Items:    items,
+		Total:    res.Total,
+		Page:     page,
+		PageSize: size,
+		HasNext:  len(items) > 0 && int64(page*size) < res.Total,
+		HasPrev:  page > 1,
+		c:        client,
+		query:    query,
+		lastSort: res.LastSortValues(),
+	}, nil
+}
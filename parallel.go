@@ -0,0 +1,51 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// ParallelWrite writes all documents using concurrency goroutines. Errors of
+// all failed writes are joined together. Once ctx is cancelled remaining
+// documents are not written and ctx error is returned.
+func (c *Client) ParallelWrite(ctx context.Context, docs []json.RawMessage, concurrency int) error {
+	jobs := make(chan json.RawMessage)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for range max(concurrency, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for doc := range jobs {
+				if _, err := c.WriteContext(ctx, doc); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, doc := range docs {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- doc:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
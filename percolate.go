@@ -0,0 +1,72 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// PercolateHit is a stored percolator query matching the percolated document.
+type PercolateHit struct {
+	QueryID string
+	Score   float64
+}
+
+// percolatorIndex returns name of the index storing client's percolator queries.
+func (c *Client) percolatorIndex() string {
+	return c.currentIndex() + "-percolator"
+}
+
+// StorePercolatorQuery stores query with the given ID in client's percolator index ("{index}-percolator").
+func (c *Client) StorePercolatorQuery(ctx context.Context, id string, query json.RawMessage) error {
+	endpoint, err := c.endpoint("api", c.percolatorIndex(), "_doc", id)
+	if err != nil {
+		return err
+	}
+
+	return c.doJSON(ctx, http.MethodPut, endpoint, map[string]json.RawMessage{"query": query}, nil)
+}
+
+// DeletePercolatorQuery deletes query with the given ID from client's percolator index.
+func (c *Client) DeletePercolatorQuery(ctx context.Context, id string) error {
+	endpoint, err := c.endpoint("api", c.percolatorIndex(), "_doc", id)
+	if err != nil {
+		return err
+	}
+
+	err = c.doJSON(ctx, http.MethodDelete, endpoint, nil, nil)
+	if isStatus(err, http.StatusNotFound) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// Percolate returns stored percolator queries matching doc.
+func (c *Client) Percolate(ctx context.Context, doc json.RawMessage) ([]PercolateHit, error) {
+	endpoint, err := c.endpoint("es", c.percolatorIndex(), "_search")
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := json.Marshal(map[string]any{
+		"query": map[string]any{
+			"percolate": map[string]any{"field": "query", "document": doc},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.search(ctx, c.readEndpoint(endpoint), query)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]PercolateHit, len(res.Hits))
+	for i, hit := range res.Hits {
+		hits[i] = PercolateHit{QueryID: hit.ID, Score: hit.Score}
+	}
+
+	return hits, nil
+}
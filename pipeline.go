@@ -0,0 +1,42 @@
+package zincmetric
+
+import "fmt"
+
+// gapPolicies are the ways pipeline aggregations deal with buckets having no value.
+var gapPolicies = map[string]bool{"skip": true, "insert_zeros": true, "keep_values": true}
+
+// MovingAverage computes average of window preceding values of bucketsPath metric
+// (e.g. "avg_latency" of sibling aggregation) in each parent histogram bucket.
+// Result is MetricResult in every bucket.
+func (AggregationBuilder) MovingAverage(name, bucketsPath string, window int) Aggregation {
+	if window <= 0 {
+		return aggregation{name: name, err: fmt.Errorf("invalid moving average window %d", window)}
+	}
+
+	return aggregation{name: name, typ: "moving_fn", body: map[string]any{
+		"buckets_path": bucketsPath,
+		"window":       window,
+		"script":       "MovingFunctions.unweightedAvg(values)",
+	}}
+}
+
+// CumulativeSum computes running total of bucketsPath metric in each parent histogram bucket.
+// Result is MetricResult in every bucket.
+func (AggregationBuilder) CumulativeSum(name, bucketsPath string) Aggregation {
+	return aggregation{name: name, typ: "cumulative_sum", body: map[string]any{"buckets_path": bucketsPath}}
+}
+
+// Derivative computes change of bucketsPath metric since the previous parent histogram bucket.
+// gapPolicy is one of "skip", "insert_zeros" or "keep_values", empty uses the server default.
+// Result is MetricResult in every bucket but the first.
+func (AggregationBuilder) Derivative(name, bucketsPath string, gapPolicy string) Aggregation {
+	body := map[string]any{"buckets_path": bucketsPath}
+	if gapPolicy != "" {
+		if !gapPolicies[gapPolicy] {
+			return aggregation{name: name, err: fmt.Errorf("invalid gap policy %q", gapPolicy)}
+		}
+		body["gap_policy"] = gapPolicy
+	}
+
+	return aggregation{name: name, typ: "derivative", body: body}
+}
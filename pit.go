@@ -0,0 +1,87 @@
+package zincmetric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// pitResponse is the open point-in-time response.
+type pitResponse struct {
+	ID string `json:"id"`
+}
+
+// OpenPIT opens a point-in-time on client's index, so consecutive searches see
+// a consistent view of the index regardless of changes made in the meantime.
+func (c *Client) OpenPIT(ctx context.Context, keepAlive time.Duration) (string, error) {
+	endpoint, err := c.endpoint("es", c.currentIndex(), "_pit")
+	if err != nil {
+		return "", err
+	}
+
+	var resp pitResponse
+	err = c.doJSON(ctx, http.MethodPost, endpoint+"?keep_alive="+url.QueryEscape(esDuration(keepAlive)), nil, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.ID, nil
+}
+
+// ClosePIT closes point-in-time opened using OpenPIT.
+func (c *Client) ClosePIT(ctx context.Context, pitID string) error {
+	endpoint, err := c.endpoint("es", "_pit")
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(pitResponse{ID: pitID})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(ctx, http.MethodDelete, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// PITOption runs search against point-in-time opened using OpenPIT,
+// extending its lifetime by keepAlive.
+func PITOption(pitID string, keepAlive time.Duration) SearchOption {
+	return func(r *searchRequest) error {
+		r.indexless = true // Index is implied by the PIT.
+		return r.set("pit", map[string]string{
+			"id":         pitID,
+			"keep_alive": esDuration(keepAlive),
+		})
+	}
+}
+
+// searchAfter sets search_after sort values to continue from.
+func searchAfter(values []json.RawMessage) SearchOption {
+	return func(r *searchRequest) error {
+		if len(values) == 0 {
+			return nil
+		}
+		return r.set("search_after", values)
+	}
+}
+
+// defaultPITSort is PIT pagination tiebreaker used when query has no sort.
+var defaultPITSort = json.RawMessage(`[{"_shard_doc":"asc"}]`)
+
+// withDefaultSort sets sort, if the query doesn't have one already.
+func withDefaultSort(sort json.RawMessage) SearchOption {
+	return func(r *searchRequest) error {
+		if _, ok := r.body["sort"]; !ok {
+			r.body["sort"] = sort
+		}
+		return nil
+	}
+}
@@ -0,0 +1,38 @@
+package zincmetric
+
+import (
+	"net/http"
+)
+
+// ConnectionPool is an HTTP transport shared by multiple clients, see WithConnectionPool.
+type ConnectionPool struct {
+	transport *http.Transport
+}
+
+// NewConnectionPool creates connection pool keeping at most maxConns connections per host.
+func NewConnectionPool(maxConns int) *ConnectionPool {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = maxConns
+	t.MaxIdleConnsPerHost = maxConns
+	t.MaxConnsPerHost = maxConns
+
+	return &ConnectionPool{transport: t}
+}
+
+// Transport returns HTTP transport used by the pool.
+func (p *ConnectionPool) Transport() http.RoundTripper {
+	return p.transport
+}
+
+// Close closes all idle pool connections. Pool can still be used afterwards.
+func (p *ConnectionPool) Close() {
+	p.transport.CloseIdleConnections()
+}
+
+// useConnectionPool replaces client transport with the pool transport,
+// the HTTP client given with WithHttpClient is copied and left untouched.
+func (c *Client) useConnectionPool() {
+	client := *c.client
+	client.Transport = c.pool.transport
+	c.client = &client
+}
@@ -0,0 +1,51 @@
+package zincmetric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// ProfileResult is a search result with query execution profile.
+type ProfileResult struct {
+	SearchResult
+	// Profile holds shard level timing of query components.
+	Profile json.RawMessage
+}
+
+// profileResponse is the wire format of profiled search response.
+type profileResponse struct {
+	searchResponse
+	Profile json.RawMessage `json:"profile"`
+}
+
+// ProfileSearch runs query with profiling enabled. Results are never served from the query cache.
+func (c *Client) ProfileSearch(ctx context.Context, query json.RawMessage) (*ProfileResult, error) {
+	req, err := newSearchRequest(query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := req.set("profile", true); err != nil {
+		return nil, err
+	}
+
+	endpoint, body, err := req.encode(c)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var pr profileResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pr); err != nil {
+		return nil, err
+	}
+
+	return &ProfileResult{SearchResult: *pr.result(), Profile: pr.Profile}, nil
+}
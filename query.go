@@ -0,0 +1,502 @@
+package zincmetric
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Query is a search query clause built by QueryBuilder.
+type Query interface {
+	Build() (json.RawMessage, error)
+}
+
+// QueryBuilder builds search query clauses, e.g.
+//
+//	var qb QueryBuilder
+//	q := qb.Bool().Must(qb.MatchPhrase("message", "disk full", 1))
+type QueryBuilder struct{}
+
+// SearchQuery sets search request query to the built query.
+func SearchQuery(q Query) SearchOption {
+	return func(r *searchRequest) error {
+		b, err := q.Build()
+		if err != nil {
+			return err
+		}
+
+		r.body["query"] = b
+		return nil
+	}
+}
+
+// clause is a query consisting of a single named clause, e.g. {"match_phrase": {...}}.
+type clause struct {
+	name string
+	body any
+	err  error // reported by Build, so builder methods stay chainable
+}
+
+func (q clause) Build() (json.RawMessage, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+
+	return json.Marshal(map[string]any{q.name: q.body})
+}
+
+// queryJSON marshals nested query using its Build method.
+type queryJSON struct {
+	q Query
+}
+
+func (q queryJSON) MarshalJSON() ([]byte, error) {
+	if q.q == nil {
+		return nil, fmt.Errorf("nil query")
+	}
+
+	return q.q.Build()
+}
+
+// queriesJSON wraps nested queries for marshaling.
+func queriesJSON(qs []Query) []queryJSON {
+	out := make([]queryJSON, len(qs))
+	for i, q := range qs {
+		out[i] = queryJSON{q}
+	}
+
+	return out
+}
+
+// namedQueriesJSON wraps nested queries keyed by name for marshaling.
+func namedQueriesJSON(qs map[string]Query) map[string]queryJSON {
+	out := make(map[string]queryJSON, len(qs))
+	for name, q := range qs {
+		out[name] = queryJSON{q}
+	}
+
+	return out
+}
+
+// BoolQuery combines queries using must, should, must_not and filter clauses.
+type BoolQuery struct {
+	must, should, mustNot, filter []Query
+}
+
+// Bool creates an empty bool query.
+func (QueryBuilder) Bool() *BoolQuery {
+	return &BoolQuery{}
+}
+
+// Must adds queries documents must match.
+func (b *BoolQuery) Must(qs ...Query) *BoolQuery {
+	b.must = append(b.must, qs...)
+	return b
+}
+
+// Should adds queries documents should match.
+func (b *BoolQuery) Should(qs ...Query) *BoolQuery {
+	b.should = append(b.should, qs...)
+	return b
+}
+
+// MustNot adds queries documents must not match.
+func (b *BoolQuery) MustNot(qs ...Query) *BoolQuery {
+	b.mustNot = append(b.mustNot, qs...)
+	return b
+}
+
+// Filter adds queries documents must match, without affecting score.
+func (b *BoolQuery) Filter(qs ...Query) *BoolQuery {
+	b.filter = append(b.filter, qs...)
+	return b
+}
+
+func (b *BoolQuery) Build() (json.RawMessage, error) {
+	body := make(map[string]any, 4)
+	for key, qs := range map[string][]Query{
+		"must":     b.must,
+		"should":   b.should,
+		"must_not": b.mustNot,
+		"filter":   b.filter,
+	} {
+		if len(qs) > 0 {
+			body[key] = queriesJSON(qs)
+		}
+	}
+
+	return clause{name: "bool", body: body}.Build()
+}
+
+// MatchPhrase matches documents containing phrase in field,
+// allowing up to slop positions between the phrase terms.
+func (QueryBuilder) MatchPhrase(field, phrase string, slop int) Query {
+	body := map[string]any{"query": phrase}
+	if slop > 0 {
+		body["slop"] = slop
+	}
+
+	return clause{name: "match_phrase", body: map[string]any{field: body}}
+}
+
+// MatchPhrasePrefix matches documents containing phrase in field, with the last term used as prefix
+// expanded to at most maxExpansions terms (0 means ZincSearch default).
+func (QueryBuilder) MatchPhrasePrefix(field, prefix string, maxExpansions int) Query {
+	body := map[string]any{"query": prefix}
+	if maxExpansions > 0 {
+		body["max_expansions"] = maxExpansions
+	}
+
+	return clause{name: "match_phrase_prefix", body: map[string]any{field: body}}
+}
+
+// nestedScoreModes are score modes allowed for nested queries.
+var nestedScoreModes = map[string]bool{"avg": true, "max": true, "min": true, "none": true, "sum": true}
+
+// Nested matches documents with nested objects at path matching query.
+// scoreMode is one of "avg", "max", "min", "none" or "sum", empty means ZincSearch default.
+func (QueryBuilder) Nested(path string, query Query, scoreMode string) Query {
+	body := map[string]any{"path": path, "query": queryJSON{query}}
+	if scoreMode != "" {
+		body["score_mode"] = scoreMode
+	}
+
+	var err error
+	if scoreMode != "" && !nestedScoreModes[scoreMode] {
+		err = fmt.Errorf("invalid nested query score mode %q", scoreMode)
+	}
+
+	return clause{name: "nested", body: body, err: err}
+}
+
+// geoDistanceRe matches distances like "12km", "1.5mi" or "200m".
+var geoDistanceRe = regexp.MustCompile(`^\d+(\.\d+)?(km|mi|m)$`)
+
+// GeoPoint is a geographic point used by geo queries and aggregations.
+type GeoPoint struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// GeoDistance matches documents with field geo point within distance (e.g. "10km", "5mi" or "200m")
+// of the given point.
+func (QueryBuilder) GeoDistance(field string, lat, lon float64, distance string) Query {
+	var err error
+	if !geoDistanceRe.MatchString(distance) {
+		err = fmt.Errorf("invalid geo distance %q, expected number followed by km, mi or m", distance)
+	}
+
+	body := map[string]any{"distance": distance, field: GeoPoint{Lat: lat, Lon: lon}}
+
+	return clause{name: "geo_distance", body: body, err: err}
+}
+
+// GeoBoundingBox matches documents with field geo point inside the rectangle
+// given by its top left and bottom right corners.
+func (QueryBuilder) GeoBoundingBox(field string, topLat, leftLon, bottomLat, rightLon float64) Query {
+	var err error
+	if topLat < bottomLat {
+		err = fmt.Errorf("invalid geo bounding box, top %v is below bottom %v", topLat, bottomLat)
+	}
+
+	body := map[string]any{field: map[string]GeoPoint{
+		"top_left":     {Lat: topLat, Lon: leftLon},
+		"bottom_right": {Lat: bottomLat, Lon: rightLon},
+	}}
+
+	return clause{name: "geo_bounding_box", body: body, err: err}
+}
+
+// SpanQuery is a query matching term positions, it can be combined using other span queries.
+type SpanQuery interface {
+	Query
+	// AsQuery returns the span query as a plain query.
+	AsQuery() Query
+	span()
+}
+
+// spanClause is a clause of a span query.
+type spanClause struct {
+	clause
+}
+
+func (q spanClause) AsQuery() Query {
+	return q.clause
+}
+
+func (spanClause) span() {}
+
+// spansJSON wraps nested span queries for marshaling.
+func spansJSON(qs []SpanQuery) []queryJSON {
+	out := make([]queryJSON, len(qs))
+	for i, q := range qs {
+		out[i] = queryJSON{q}
+	}
+
+	return out
+}
+
+// SpanTerm matches term positions of value in field.
+func (QueryBuilder) SpanTerm(field, value string) SpanQuery {
+	return spanClause{clause{name: "span_term", body: map[string]string{field: value}}}
+}
+
+// SpanNear matches spans of clauses that are within slop positions of each other,
+// in the given order if inOrder is set.
+func (QueryBuilder) SpanNear(clauses []SpanQuery, slop int, inOrder bool) SpanQuery {
+	var err error
+	if len(clauses) == 0 {
+		err = fmt.Errorf("span near query requires at least one clause")
+	}
+
+	body := map[string]any{"clauses": spansJSON(clauses), "slop": slop, "in_order": inOrder}
+
+	return spanClause{clause{name: "span_near", body: body, err: err}}
+}
+
+// SpanFirst matches spans of match ending at most at end position of the field.
+func (QueryBuilder) SpanFirst(match SpanQuery, end int) SpanQuery {
+	body := map[string]any{"match": queryJSON{match}, "end": end}
+
+	return spanClause{clause{name: "span_first", body: body}}
+}
+
+// Fuzzy matches documents with field terms within fuzziness (0, 1 or 2) edits of value.
+// First prefixLength characters must match exactly and the term is expanded to at most
+// maxExpansions variations.
+func (QueryBuilder) Fuzzy(field, value string, fuzziness int, prefixLength int, maxExpansions int) Query {
+	var err error
+	switch {
+	case fuzziness < 0 || fuzziness > 2:
+		err = fmt.Errorf("invalid fuzziness %d, expected 0, 1 or 2", fuzziness)
+	case prefixLength < 0:
+		err = fmt.Errorf("invalid fuzzy prefix length %d", prefixLength)
+	case maxExpansions <= 0:
+		err = fmt.Errorf("invalid fuzzy max expansions %d", maxExpansions)
+	}
+
+	body := map[string]any{field: map[string]any{
+		"value":          value,
+		"fuzziness":      fuzziness,
+		"prefix_length":  prefixLength,
+		"max_expansions": maxExpansions,
+	}}
+
+	return clause{name: "fuzzy", body: body, err: err}
+}
+
+// FuzzyMatch is Fuzzy with fuzziness of 1, prefix length of 2 and 50 max expansions.
+func (qb QueryBuilder) FuzzyMatch(field, value string) Query {
+	return qb.Fuzzy(field, value, 1, 2, 50)
+}
+
+// Wildcard matches documents with field terms matching pattern, where "*" matches any
+// characters and "?" a single one. Rewrite sets how matching terms are scored
+// (e.g. "constant_score" or "scoring_boolean"), empty means ZincSearch default.
+//
+// Patterns starting with a wildcard have to check every term of the field, which is slow on large indexes.
+func (QueryBuilder) Wildcard(field, pattern string, rewrite string, caseInsensitive bool) Query {
+	var err error
+	if !strings.ContainsAny(pattern, "*?") {
+		err = fmt.Errorf("wildcard pattern %q has no wildcards", pattern)
+	}
+
+	body := map[string]any{"value": pattern}
+	if rewrite != "" {
+		body["rewrite"] = rewrite
+	}
+	if caseInsensitive {
+		body["case_insensitive"] = true
+	}
+
+	return clause{name: "wildcard", body: map[string]any{field: body}, err: err}
+}
+
+// WildcardAll matches documents with any of fields matching pattern, see Wildcard.
+func (qb QueryBuilder) WildcardAll(fields []string, pattern string) Query {
+	qs := make([]Query, len(fields))
+	for i, field := range fields {
+		qs[i] = qb.Wildcard(field, pattern, "", false)
+	}
+
+	return qb.Bool().Should(qs...)
+}
+
+// Term matches documents with exact value in field.
+func (QueryBuilder) Term(field string, value any) Query {
+	var err error
+	if value == nil {
+		err = fmt.Errorf("term query on %q has nil value", field)
+	}
+
+	return clause{name: "term", body: map[string]any{field: value}, err: err}
+}
+
+// notQuery is a negated query, see QueryBuilder.Not.
+type notQuery struct {
+	q Query
+}
+
+// Not matches documents not matching q.
+func (QueryBuilder) Not(q Query) Query {
+	return notQuery{q}
+}
+
+func (n notQuery) Build() (json.RawMessage, error) {
+	return QueryBuilder{}.Bool().MustNot(n.q).Build()
+}
+
+// filterClauses are exact match queries not needing scoring.
+var filterClauses = map[string]bool{
+	"term": true, "terms": true, "range": true, "exists": true,
+	"geo_distance": true, "geo_bounding_box": true,
+}
+
+// AutoBool combines queries into bool query inferring clause of each: term, range and other
+// exact match queries become filter, queries negated using Not become must_not and
+// the rest (full-text queries) become must.
+func (qb QueryBuilder) AutoBool(queries ...Query) Query {
+	b := qb.Bool()
+	for _, q := range queries {
+		switch q := q.(type) {
+		case notQuery:
+			b.MustNot(q.q)
+		case clause:
+			if filterClauses[q.name] {
+				b.Filter(q)
+			} else {
+				b.Must(q)
+			}
+		default:
+			b.Must(q)
+		}
+	}
+
+	return b
+}
+
+// Prefix matches documents with a field term starting with value. Unlike MatchPhrasePrefix,
+// value isn't analyzed and matched against single terms, and unlike Wildcard it can't
+// contain wildcards, which makes it the cheapest option for autocomplete.
+func (QueryBuilder) Prefix(field, value string) Query {
+	var err error
+	if value == "" {
+		err = fmt.Errorf("prefix query on %q has empty prefix", field)
+	}
+
+	return clause{name: "prefix", body: map[string]any{field: map[string]string{"value": value}}, err: err}
+}
+
+// MultiPrefix matches documents with any of fields containing a phrase with its last term starting with value.
+func (QueryBuilder) MultiPrefix(fields []string, value string) Query {
+	var err error
+	if value == "" {
+		err = fmt.Errorf("multi prefix query has empty prefix")
+	}
+
+	body := map[string]any{"query": value, "fields": fields, "type": "phrase_prefix"}
+
+	return clause{name: "multi_match", body: body, err: err}
+}
+
+// Exists matches documents where field has a value.
+func (QueryBuilder) Exists(field string) Query {
+	return clause{name: "exists", body: map[string]string{"field": field}}
+}
+
+// Missing matches documents where field is null or absent.
+func (qb QueryBuilder) Missing(field string) Query {
+	return qb.Not(qb.Exists(field))
+}
+
+var (
+	// multiMatchTypes are allowed multi match query types.
+	multiMatchTypes = map[string]bool{
+		"best_fields": true, "most_fields": true, "cross_fields": true,
+		"phrase": true, "phrase_prefix": true, "bool_prefix": true,
+	}
+	// boostedFieldRe matches field names with optional boost, e.g. "title^3".
+	boostedFieldRe = regexp.MustCompile(`^[^^]+(\^\d+(\.\d+)?)?$`)
+)
+
+// MultiMatch matches query against multiple fields, boosted using "field^boost" notation
+// (e.g. "title^3"). MatchType is one of "best_fields", "most_fields", "cross_fields", "phrase",
+// "phrase_prefix" or "bool_prefix" and operator "and" or "or", empty values mean ZincSearch defaults.
+func (QueryBuilder) MultiMatch(fields []string, query string, matchType string, operator string) Query {
+	var err error
+	switch {
+	case matchType != "" && !multiMatchTypes[matchType]:
+		err = fmt.Errorf("invalid multi match type %q", matchType)
+	case operator != "" && operator != "and" && operator != "or":
+		err = fmt.Errorf("invalid multi match operator %q", operator)
+	}
+	for _, f := range fields {
+		if err == nil && !boostedFieldRe.MatchString(f) {
+			err = fmt.Errorf("invalid multi match field %q", f)
+		}
+	}
+
+	body := map[string]any{"query": query, "fields": fields}
+	if matchType != "" {
+		body["type"] = matchType
+	}
+	if operator != "" {
+		body["operator"] = operator
+	}
+
+	return clause{name: "multi_match", body: body, err: err}
+}
+
+// Named matches documents matching query, tagging them with name in SearchHit.MatchedQueries.
+func (QueryBuilder) Named(query Query, name string) Query {
+	var err error
+	if query == nil {
+		err = fmt.Errorf("nil query named %q", name)
+	}
+
+	return clause{name: "bool", body: map[string]any{"must": queryJSON{query}, "_name": name}, err: err}
+}
+
+// ConstantScore matches documents matching filter, giving all of them score boost instead of computing relevance.
+func (QueryBuilder) ConstantScore(filter Query, boost float32) Query {
+	var err error
+	if filter == nil {
+		err = fmt.Errorf("nil constant score filter")
+	}
+
+	return clause{name: "constant_score", body: map[string]any{"filter": queryJSON{filter}, "boost": boost}, err: err}
+}
+
+// Pinned matches documents matching organic query, putting documents with ids first in the given
+// order. Pinned documents are returned even if they don't match organic.
+func (QueryBuilder) Pinned(ids []string, organic Query) Query {
+	var err error
+	switch {
+	case len(ids) == 0:
+		err = fmt.Errorf("pinned query has no ids")
+	case organic == nil:
+		err = fmt.Errorf("nil pinned organic query")
+	}
+
+	return clause{name: "pinned", body: map[string]any{"ids": ids, "organic": queryJSON{organic}}, err: err}
+}
+
+// DisjMax matches documents matching any of queries, scoring them by the best matching query plus
+// tieBreaker (0-1) times scores of the other matching queries.
+func (QueryBuilder) DisjMax(queries []Query, tieBreaker float32) Query {
+	var err error
+	switch {
+	case len(queries) == 0:
+		err = fmt.Errorf("dis_max query has no queries")
+	case tieBreaker < 0 || tieBreaker > 1:
+		err = fmt.Errorf("invalid dis_max tie breaker %v, expected value between 0 and 1", tieBreaker)
+	}
+
+	body := map[string]any{"queries": queriesJSON(queries)}
+	if tieBreaker > 0 {
+		body["tie_breaker"] = tieBreaker
+	}
+
+	return clause{name: "dis_max", body: body, err: err}
+}
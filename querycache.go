@@ -0,0 +1,98 @@
+package zincmetric
+
+import (
+	"crypto/sha256"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// queryCache caches search results by request hash.
+type queryCache struct {
+	ttl        time.Duration
+	maxEntries int64
+
+	entries sync.Map // [sha256.Size]byte -> *queryCacheEntry
+	size    atomic.Int64
+}
+
+type queryCacheEntry struct {
+	result  *SearchResult
+	expires time.Time
+}
+
+func newQueryCache(ttl time.Duration, maxEntries int) *queryCache {
+	return &queryCache{ttl: ttl, maxEntries: int64(maxEntries)}
+}
+
+// get returns cached result of the request, if there is one that's not expired.
+func (q *queryCache) get(key []byte) (*SearchResult, bool) {
+	hash := sha256.Sum256(key)
+	v, ok := q.entries.Load(hash)
+	if !ok {
+		return nil, false
+	}
+
+	entry := v.(*queryCacheEntry)
+	if time.Now().After(entry.expires) {
+		if q.entries.CompareAndDelete(hash, entry) {
+			q.size.Add(-1)
+		}
+		return nil, false
+	}
+
+	return entry.result.clone(), true
+}
+
+// put caches request result. When cache is full, expired entries are evicted
+// first and the result isn't cached if there's still no room.
+func (q *queryCache) put(key []byte, result *SearchResult) {
+	if q.size.Load() >= q.maxEntries {
+		q.evictExpired()
+		if q.size.Load() >= q.maxEntries {
+			return
+		}
+	}
+
+	entry := &queryCacheEntry{result: result.clone(), expires: time.Now().Add(q.ttl)}
+	if _, loaded := q.entries.Swap(sha256.Sum256(key), entry); !loaded {
+		q.size.Add(1)
+	}
+}
+
+// evictExpired removes all expired entries.
+func (q *queryCache) evictExpired() {
+	now := time.Now()
+	q.entries.Range(func(key, v any) bool {
+		if now.After(v.(*queryCacheEntry).expires) && q.entries.CompareAndDelete(key, v) {
+			q.size.Add(-1)
+		}
+		return true
+	})
+}
+
+// clear removes all entries.
+func (q *queryCache) clear() {
+	q.entries.Range(func(key, v any) bool {
+		if q.entries.CompareAndDelete(key, v) {
+			q.size.Add(-1)
+		}
+		return true
+	})
+}
+
+// clone copies search result, so cached results can't be modified by callers.
+func (r *SearchResult) clone() *SearchResult {
+	cp := *r
+	cp.Hits = slices.Clone(r.Hits)
+	return &cp
+}
+
+// InvalidateQueryCache clears search results cached by WithQueryCache,
+// should be called after writes that must be visible in searches.
+func (c *Client) InvalidateQueryCache() {
+	if c.queryCache != nil {
+		c.queryCache.clear()
+	}
+}
@@ -0,0 +1,148 @@
+package zincmetric
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Query cost ratings, from the cheapest.
+const (
+	QueryCostLow      = "low"
+	QueryCostMedium   = "medium"
+	QueryCostHigh     = "high"
+	QueryCostVeryHigh = "very_high"
+)
+
+// queryCostRatings are query cost ratings ordered by cost.
+var queryCostRatings = []string{QueryCostLow, QueryCostMedium, QueryCostHigh, QueryCostVeryHigh}
+
+// QueryCost is a rough static estimate of search request cost, see EstimateQueryCost.
+type QueryCost struct {
+	// EstimatedDocumentsScanned is the number of hits collected to serve the requested page (from + size).
+	EstimatedDocumentsScanned int64
+	// WildcardCount counts wildcard and regexp clauses.
+	WildcardCount int
+	// FuzzyCount counts fuzzy clauses and fuzzy match clauses.
+	FuzzyCount int
+	// AggregationDepth is the maximum aggregation nesting level, 0 without aggregations.
+	AggregationDepth int
+	// OverallRating is one of QueryCostLow, QueryCostMedium, QueryCostHigh or QueryCostVeryHigh.
+	OverallRating string
+}
+
+// EstimateQueryCost estimates cost of search request body without running it. Wildcards (especially
+// leading ones), fuzzy matching, scripts, deep aggregations and deep pagination raise the rating.
+func EstimateQueryCost(query json.RawMessage) (QueryCost, error) {
+	var body map[string]any
+	if len(query) > 0 {
+		if err := json.Unmarshal(query, &body); err != nil {
+			return QueryCost{}, err
+		}
+	}
+
+	cost := QueryCost{EstimatedDocumentsScanned: 10} // default page size
+	if size, ok := body["size"].(float64); ok {
+		cost.EstimatedDocumentsScanned = int64(size)
+	}
+	if from, ok := body["from"].(float64); ok {
+		cost.EstimatedDocumentsScanned += int64(from)
+	}
+
+	var points int
+	walkQueryCost(body, 0, &cost, &points)
+
+	points += 2*cost.WildcardCount + 2*cost.FuzzyCount + max(cost.AggregationDepth-1, 0)
+	switch {
+	case cost.EstimatedDocumentsScanned > 10000:
+		points += 3
+	case cost.EstimatedDocumentsScanned > 1000:
+		points++
+	}
+
+	switch {
+	case points <= 1:
+		cost.OverallRating = QueryCostLow
+	case points <= 4:
+		cost.OverallRating = QueryCostMedium
+	case points <= 8:
+		cost.OverallRating = QueryCostHigh
+	default:
+		cost.OverallRating = QueryCostVeryHigh
+	}
+
+	return cost, nil
+}
+
+// walkQueryCost counts expensive clauses of decoded JSON v, aggDepth is the current aggregation nesting.
+func walkQueryCost(v any, aggDepth int, cost *QueryCost, points *int) {
+	switch v := v.(type) {
+	case []any:
+		for _, e := range v {
+			walkQueryCost(e, aggDepth, cost, points)
+		}
+	case map[string]any:
+		for key, e := range v {
+			depth := aggDepth
+			switch key {
+			case "aggs", "aggregations":
+				depth++
+				cost.AggregationDepth = max(cost.AggregationDepth, depth)
+			case "wildcard", "regexp":
+				cost.WildcardCount++
+				if leadingWildcard(e) {
+					*points += 2
+				}
+			case "fuzzy", "fuzziness":
+				cost.FuzzyCount++
+				continue // fuzzy clause has no nested clauses
+			case "script", "scripted_metric", "map_script":
+				*points += 3
+			}
+			walkQueryCost(e, depth, cost, points)
+		}
+	}
+}
+
+// leadingWildcard reports whether wildcard or regexp clause body has a pattern starting with a wildcard.
+func leadingWildcard(body any) bool {
+	fields, ok := body.(map[string]any)
+	if !ok {
+		return false
+	}
+
+	for _, f := range fields {
+		pattern, ok := f.(string)
+		if opts, isMap := f.(map[string]any); isMap {
+			pattern, ok = opts["value"].(string)
+			if !ok {
+				pattern, ok = opts["wildcard"].(string)
+			}
+		}
+		if ok && (strings.HasPrefix(pattern, "*") || strings.HasPrefix(pattern, "?") || strings.HasPrefix(pattern, ".")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkQueryCost returns error matching ErrQueryTooExpensive if search request body is rated over WithMaxQueryCost.
+func (c *Client) checkQueryCost(body json.RawMessage) error {
+	limit := slices.Index(queryCostRatings, c.maxQueryCost)
+	if limit < 0 {
+		return fmt.Errorf("invalid max query cost rating %q", c.maxQueryCost)
+	}
+
+	cost, err := EstimateQueryCost(body)
+	if err != nil {
+		return err
+	}
+
+	if slices.Index(queryCostRatings, cost.OverallRating) > limit {
+		return fmt.Errorf("%w: rated %s, max %s", ErrQueryTooExpensive, cost.OverallRating, c.maxQueryCost)
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+package zincmetric
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// QueryToSQL renders query (a query clause or full search request body) as an SQL-like WHERE
+// clause, e.g. `WHERE level = 'error' AND (host LIKE 'web' OR NOT (env = 'dev'))`. The output isn't
+// executable, it's meant to make complex queries readable when debugging. Clauses other than
+// match, match_phrase, term, terms, range, exists, match_all and bool are rendered as name(json).
+func QueryToSQL(query json.RawMessage) (string, error) {
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(query, &body); err != nil {
+		return "", err
+	}
+	q, ok := body["query"]
+	if !ok && (len(body) == 0 || searchRequestKeys(body)) {
+		return "WHERE TRUE", nil
+	}
+	if ok {
+		query = q
+	}
+
+	expr, err := sqlExpr(query)
+	if err != nil {
+		return "", err
+	}
+
+	return "WHERE " + expr, nil
+}
+
+// searchRequestKeys reports whether body has search request keys, so it isn't a query clause.
+func searchRequestKeys(body map[string]json.RawMessage) bool {
+	for _, key := range []string{"size", "from", "sort", "aggs", "_source"} {
+		if _, ok := body[key]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sqlExpr renders query clause as SQL-like expression.
+func sqlExpr(query json.RawMessage) (string, error) {
+	var q map[string]json.RawMessage
+	if err := json.Unmarshal(query, &q); err != nil {
+		return "", err
+	}
+	if len(q) != 1 {
+		return "", fmt.Errorf("query clause must have a single key, got %d", len(q))
+	}
+
+	var name string
+	var body json.RawMessage
+	for name, body = range q { // the only key
+	}
+
+	switch name {
+	case "bool":
+		return sqlBool(body)
+	case "match_all":
+		return "TRUE", nil
+	case "exists":
+		var exists struct {
+			Field string `json:"field"`
+		}
+		if err := json.Unmarshal(body, &exists); err != nil {
+			return "", err
+		}
+		return exists.Field + " IS NOT NULL", nil
+	case "match", "match_phrase":
+		return sqlField(body, "query", func(field string, v json.RawMessage) string {
+			return field + " LIKE " + sqlValue(v)
+		})
+	case "term":
+		return sqlField(body, "value", func(field string, v json.RawMessage) string {
+			return field + " = " + sqlValue(v)
+		})
+	case "terms":
+		return sqlField(body, "", func(field string, v json.RawMessage) string {
+			var values []json.RawMessage
+			if err := json.Unmarshal(v, &values); err != nil {
+				return field + " IN " + string(v)
+			}
+			rendered := make([]string, len(values))
+			for i, value := range values {
+				rendered[i] = sqlValue(value)
+			}
+			return field + " IN (" + strings.Join(rendered, ", ") + ")"
+		})
+	case "range":
+		return sqlField(body, "", sqlRange)
+	default:
+		return name + "(" + string(body) + ")", nil
+	}
+}
+
+// sqlOptions are clause options that aren't field names.
+var sqlOptions = map[string]bool{"boost": true, "_name": true}
+
+// sqlField renders single field clause like {"field": value} or {"field": {valueKey: value}}.
+func sqlField(body json.RawMessage, valueKey string, render func(field string, v json.RawMessage) string) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return "", err
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		if !sqlOptions[k] {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) != 1 {
+		return "", fmt.Errorf("field clause must have a single field, got %d", len(keys))
+	}
+
+	v := fields[keys[0]]
+	if valueKey != "" {
+		var opts map[string]json.RawMessage
+		if json.Unmarshal(v, &opts) == nil {
+			if inner, ok := opts[valueKey]; ok {
+				v = inner
+			}
+		}
+	}
+
+	return render(keys[0], v), nil
+}
+
+// sqlRange renders range bounds of field.
+func sqlRange(field string, v json.RawMessage) string {
+	var bounds map[string]json.RawMessage
+	if err := json.Unmarshal(v, &bounds); err != nil {
+		return field + " IN RANGE " + string(v)
+	}
+
+	gte, hasGTE := bounds["gte"]
+	lte, hasLTE := bounds["lte"]
+	if hasGTE && hasLTE && len(bounds) == 2 {
+		return field + " BETWEEN " + sqlValue(gte) + " AND " + sqlValue(lte)
+	}
+
+	var conds []string
+	for _, op := range []struct{ key, sql string }{{"gt", ">"}, {"gte", ">="}, {"lt", "<"}, {"lte", "<="}} {
+		if b, ok := bounds[op.key]; ok {
+			conds = append(conds, field+" "+op.sql+" "+sqlValue(b))
+		}
+	}
+	if len(conds) == 0 {
+		return "TRUE"
+	}
+
+	return strings.Join(conds, " AND ")
+}
+
+// sqlBool renders bool query clauses.
+func sqlBool(body json.RawMessage) (string, error) {
+	var clauses map[string]json.RawMessage
+	if err := json.Unmarshal(body, &clauses); err != nil {
+		return "", err
+	}
+
+	var parts []string
+	for _, key := range []string{"must", "filter", "should", "must_not"} {
+		raw, ok := clauses[key]
+		if !ok {
+			continue
+		}
+
+		qs, err := sqlClauses(raw)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", key, err)
+		}
+		if len(qs) == 0 {
+			continue
+		}
+
+		switch key {
+		case "should":
+			if len(qs) == 1 {
+				parts = append(parts, qs[0])
+			} else {
+				parts = append(parts, "("+strings.Join(qs, " OR ")+")")
+			}
+		case "must_not":
+			for _, q := range qs {
+				parts = append(parts, "NOT ("+q+")")
+			}
+		default:
+			parts = append(parts, qs...)
+		}
+	}
+
+	switch len(parts) {
+	case 0:
+		return "TRUE", nil
+	case 1:
+		return parts[0], nil
+	}
+
+	return strings.Join(parts, " AND "), nil
+}
+
+// sqlClauses renders bool query clause, being either a single query or their array.
+func sqlClauses(raw json.RawMessage) ([]string, error) {
+	var qs []json.RawMessage
+	if err := json.Unmarshal(raw, &qs); err != nil {
+		qs = []json.RawMessage{raw}
+	}
+
+	out := make([]string, 0, len(qs))
+	for _, q := range qs {
+		expr, err := sqlExpr(q)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, expr)
+	}
+
+	return out, nil
+}
+
+// sqlValue renders JSON value as SQL literal, strings are single quoted.
+func sqlValue(v json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(v, &s); err == nil {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+
+	return strings.TrimSpace(string(v))
+}
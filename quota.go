@@ -0,0 +1,59 @@
+package zincmetric
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// quotaCheckTTL is how long index statistics are reused between quota checks.
+const quotaCheckTTL = 10 * time.Second
+
+// indexQuota limits index size, see WithIndexQuota.
+type indexQuota struct {
+	maxDocs, maxSize int64
+
+	mu    sync.Mutex
+	stats map[string]quotaStats // by index
+}
+
+type quotaStats struct {
+	IndexStats
+	expires time.Time
+}
+
+// quotaExceeded reports whether pushing docs to index would exceed WithIndexQuota quota.
+// Index statistics are cached for quotaCheckTTL and updated with the pushed documents meanwhile.
+// Quota isn't enforced if index statistics can't be retrieved.
+func (c *Client) quotaExceeded(index string, docs [][]byte) bool {
+	q := c.quota
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats, ok := q.stats[index]
+	if !ok || time.Now().After(stats.expires) {
+		s, err := c.indexStats(context.Background(), index)
+		if err != nil {
+			c.logger.Warn("failed to check index quota", logKeyIndex, index, logKeyError, err)
+			return false
+		}
+		stats = quotaStats{IndexStats: *s, expires: time.Now().Add(quotaCheckTTL)}
+	}
+
+	var size int64
+	for _, doc := range docs {
+		size += int64(len(doc))
+	}
+
+	if (q.maxDocs > 0 && stats.DocCount+int64(len(docs)) > q.maxDocs) ||
+		(q.maxSize > 0 && stats.StorageSize+size > q.maxSize) {
+		q.stats[index] = stats
+		return true
+	}
+
+	stats.DocCount += int64(len(docs))
+	stats.StorageSize += size
+	q.stats[index] = stats
+
+	return false
+}
@@ -0,0 +1,29 @@
+package zincmetric
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// observeRateLimit suspends flushing until X-RateLimit-Reset time (unix seconds)
+// once response reports no remaining requests with X-RateLimit-Remaining.
+func (c *Client) observeRateLimit(resp *http.Response) {
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return
+	}
+
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	until := time.Unix(reset, 0)
+	c.rateLimitedUntil.Store(until.UnixNano())
+	c.logger.Warn("rate limit reached, suspending flushes", "until", until)
+}
+
+// rateLimited reports whether flushing is suspended because of WithRateLimitHeaders.
+func (c *Client) rateLimited() bool {
+	return time.Now().UnixNano() < c.rateLimitedUntil.Load()
+}
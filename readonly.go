@@ -0,0 +1,68 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// SearchClient is a read-only view of the client, it can't write
+// documents or modify the index.
+type SearchClient struct {
+	c *Client
+}
+
+// ReadOnly returns read-only API of the client.
+func (c *Client) ReadOnly() *SearchClient {
+	return &SearchClient{c: c}
+}
+
+// Search runs query, see Client.Search.
+func (s *SearchClient) Search(ctx context.Context, query json.RawMessage, opts ...SearchOption) (*SearchResult, error) {
+	return s.c.Search(ctx, query, opts...)
+}
+
+// Count counts documents matching query, see Client.Count.
+func (s *SearchClient) Count(ctx context.Context, query json.RawMessage) (int64, error) {
+	return s.c.Count(ctx, query)
+}
+
+// GetDocument returns document source, see Client.GetDocument.
+func (s *SearchClient) GetDocument(ctx context.Context, id string) (json.RawMessage, error) {
+	return s.c.GetDocument(ctx, id)
+}
+
+// Exists reports whether document exists, see Client.Exists.
+func (s *SearchClient) Exists(ctx context.Context, id string) (bool, error) {
+	return s.c.Exists(ctx, id)
+}
+
+// TermVectors returns document term vectors, see Client.TermVectors.
+func (s *SearchClient) TermVectors(ctx context.Context, id string, fields []string) (json.RawMessage, error) {
+	return s.c.TermVectors(ctx, id, fields)
+}
+
+// Explain explains document score, see Client.Explain.
+func (s *SearchClient) Explain(ctx context.Context, id string, query json.RawMessage) (json.RawMessage, error) {
+	return s.c.Explain(ctx, id, query)
+}
+
+// Scroll creates a scroller, see Client.Scroll.
+func (s *SearchClient) Scroll(query json.RawMessage, keepAlive time.Duration) *Scroller {
+	return s.c.Scroll(query, keepAlive)
+}
+
+// ScanAll goes through all documents, see Client.ScanAll.
+func (s *SearchClient) ScanAll(ctx context.Context, fn func(hit SearchHit) error) error {
+	return s.c.ScanAll(ctx, fn)
+}
+
+// MultiSearch runs multiple queries, see Client.MultiSearch.
+func (s *SearchClient) MultiSearch(ctx context.Context, queries []json.RawMessage) ([]*SearchResult, error) {
+	return s.c.MultiSearch(ctx, queries)
+}
+
+// Suggest runs suggesters, see Client.Suggest.
+func (s *SearchClient) Suggest(ctx context.Context, suggest json.RawMessage) (json.RawMessage, error) {
+	return s.c.Suggest(ctx, suggest)
+}
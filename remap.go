@@ -0,0 +1,70 @@
+package zincmetric
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// RemapFields creates TransformFunc renaming JSON document keys according to mapping (old key to new key).
+// Nested keys are addressed using dot notation, e.g. "address.city" to "address.town".
+// Keys not in the mapping are left unchanged.
+func RemapFields(mapping map[string]string) TransformFunc {
+	return func(data []byte) ([]byte, error) {
+		d := json.NewDecoder(bytes.NewReader(data))
+		d.UseNumber()
+
+		var doc map[string]any
+		if err := d.Decode(&doc); err != nil {
+			return nil, err
+		}
+
+		// Take all values out first, so swapped keys don't overwrite each other.
+		values := make(map[string]any, len(mapping))
+		for from := range mapping {
+			if v, ok := takeField(doc, strings.Split(from, ".")); ok {
+				values[from] = v
+			}
+		}
+		for from, v := range values {
+			putField(doc, strings.Split(mapping[from], "."), v)
+		}
+
+		return json.Marshal(doc)
+	}
+}
+
+// takeField walks path in the document and removes the value found.
+func takeField(doc map[string]any, path []string) (any, bool) {
+	v, ok := doc[path[0]]
+	if !ok {
+		return nil, false
+	}
+
+	if len(path) > 1 {
+		if nested, ok := v.(map[string]any); ok {
+			return takeField(nested, path[1:])
+		}
+		return nil, false
+	}
+
+	delete(doc, path[0])
+
+	return v, true
+}
+
+// putField walks path in the document, creating missing objects, and sets the value.
+func putField(doc map[string]any, path []string, v any) {
+	if len(path) == 1 {
+		doc[path[0]] = v
+		return
+	}
+
+	nested, ok := doc[path[0]].(map[string]any)
+	if !ok {
+		nested = make(map[string]any)
+		doc[path[0]] = nested
+	}
+
+	putField(nested, path[1:], v)
+}
@@ -0,0 +1,237 @@
+package zincmetric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// StatusError is returned when ZincSearch service responds with non 200 status code.
+type StatusError struct {
+	StatusCode int
+	// Body holds the beginning of response body, usually containing error details.
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("not 200 response code: %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("not 200 response code: %d: %s", e.StatusCode, e.Body)
+}
+
+// maxErrorBodySize limits how much of error response body is kept in StatusError.
+const maxErrorBodySize = 1024
+
+// endpoint builds ZincSearch service URL from the given path elements.
+func (c *Client) endpoint(elem ...string) (string, error) {
+	return url.JoinPath(c.host, elem...)
+}
+
+// readEndpoint adds read routing preferences to the read request endpoint.
+func (c *Client) readEndpoint(endpoint string) string {
+	if c.preferLocal {
+		return endpoint + "?preference=_local"
+	}
+
+	return endpoint
+}
+
+// do sends an authenticated request to ZincSearch service.
+// Non 200 status code is returned as *StatusError, otherwise caller must close response body.
+func (c *Client) do(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
+	resp, err := c.roundTrip(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return nil, &StatusError{StatusCode: resp.StatusCode, Body: string(bytes.TrimSpace(b))}
+	}
+
+	return resp, nil
+}
+
+// roundTrip sends an authenticated request to ZincSearch service, response status is not checked.
+func (c *Client) roundTrip(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
+	if !c.inflight.begin() {
+		return nil, ErrClientClosed
+	}
+
+	resp, err := c.sendRequest(ctx, method, endpoint, body)
+	if err != nil {
+		c.inflight.end()
+		return nil, err
+	}
+
+	resp.Body = &trackedBody{ReadCloser: resp.Body, done: c.inflight.end}
+	return resp, nil
+}
+
+// sendRequest sends an authenticated request, see roundTrip.
+func (c *Client) sendRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
+	if debugEnabled(c.requestLogger) {
+		var b []byte
+		if body != nil {
+			var err error
+			if b, err = io.ReadAll(body); err != nil {
+				return nil, err
+			}
+			body = bytes.NewReader(b) // Body was consumed by logging.
+		}
+		c.logRequest(method, endpoint, b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.SetBasicAuth(c.user, c.pass)
+	req.Header.Set("Content-Type", c.contentType)
+	if c.acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", c.acceptEncoding)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.rateLimitHeaders {
+		c.observeRateLimit(resp)
+	}
+
+	if c.acceptEncoding != "" {
+		return decodeResponse(resp)
+	}
+
+	return resp, nil
+}
+
+// inflightRequests tracks requests whose response body wasn't closed yet.
+type inflightRequests struct {
+	mu      sync.Mutex
+	n       int
+	closing bool
+	idle    chan struct{} // closed once there are no requests after closing started
+}
+
+// begin registers a new request, false is returned once closing started.
+func (r *inflightRequests) begin() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closing {
+		return false
+	}
+	r.n++
+
+	return true
+}
+
+// end unregisters a finished request.
+func (r *inflightRequests) end() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.n--
+	if r.closing && r.n == 0 {
+		close(r.idle)
+	}
+}
+
+// close rejects new requests, returned channel is closed once all requests finish.
+func (r *inflightRequests) close() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.closing {
+		r.closing = true
+		r.idle = make(chan struct{})
+		if r.n == 0 {
+			close(r.idle)
+		}
+	}
+
+	return r.idle
+}
+
+// trackedBody is response body calling done once it's closed.
+type trackedBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (b *trackedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+
+	return err
+}
+
+// HTTPClient returns HTTP client used to communicate with ZincSearch service.
+func (c *Client) HTTPClient() *http.Client {
+	return c.client
+}
+
+// AuthenticatedRequest sends a request to ZincSearch service path (e.g. "/api/index",
+// may include query string), with the same authentication and headers as all client
+// requests. Response status code is not checked, caller must close response body.
+func (c *Client) AuthenticatedRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	path, query, hasQuery := strings.Cut(path, "?")
+	endpoint, err := c.endpoint(path)
+	if err != nil {
+		return nil, err
+	}
+	if hasQuery {
+		endpoint += "?" + query
+	}
+
+	return c.roundTrip(ctx, method, endpoint, body)
+}
+
+// logRequest logs outgoing request with body truncated to the configured size.
+func (c *Client) logRequest(method, endpoint string, body []byte) {
+	logged := string(body)
+	if len(body) > c.requestLogMaxBytes {
+		logged = string(body[:c.requestLogMaxBytes]) + "... (truncated)"
+	}
+
+	c.requestLogger.Debug("zincsearch request", "method", method, "url", endpoint, "body", logged)
+}
+
+// doJSON sends in (if not nil) as JSON request body and decodes
+// JSON response into out (if not nil).
+func (c *Client) doJSON(ctx context.Context, method, endpoint string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	resp, err := c.do(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
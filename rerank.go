@@ -0,0 +1,51 @@
+package zincmetric
+
+import "sort"
+
+// RescoreResults returns copy of hits with Score set by fn, sorted by it from the highest.
+// Hits with equal scores keep their order.
+func RescoreResults(results []SearchHit, fn func(hit SearchHit) float64) []SearchHit {
+	out := make([]SearchHit, len(results))
+	for i, hit := range results {
+		hit.Score = fn(hit)
+		out[i] = hit
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Score > out[j].Score })
+
+	return out
+}
+
+// MergeSearchResults merges hits of results (e.g. of multiple queries or indexes) ranked by fn,
+// see RescoreResults. Hits of the same document (by index and ID) are kept once.
+// Total is the sum of result totals and Took the longest took time.
+func MergeSearchResults(fn func(hit SearchHit) float64, results ...*SearchResult) *SearchResult {
+	type docKey struct{ index, id string }
+
+	merged := &SearchResult{}
+	seen := make(map[docKey]bool)
+	var hits []SearchHit
+	for _, res := range results {
+		if res == nil {
+			continue
+		}
+
+		merged.Total += res.Total
+		merged.Took = max(merged.Took, res.Took)
+		merged.TimedOut = merged.TimedOut || res.TimedOut
+		for _, hit := range res.Hits {
+			key := docKey{hit.Index, hit.ID}
+			if hit.ID != "" && seen[key] {
+				continue
+			}
+			seen[key] = true
+			hits = append(hits, hit)
+		}
+	}
+
+	merged.Hits = RescoreResults(hits, fn)
+	if len(merged.Hits) > 0 {
+		merged.MaxScore = merged.Hits[0].Score
+	}
+
+	return merged
+}
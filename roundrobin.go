@@ -0,0 +1,61 @@
+package zincmetric
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// RoundRobinWriter spreads writes evenly across multiple clients.
+type RoundRobinWriter struct {
+	clients []*Client
+	next    atomic.Uint64
+}
+
+// NewRoundRobinWriter creates RoundRobinWriter over the given clients.
+func NewRoundRobinWriter(clients []*Client) *RoundRobinWriter {
+	return &RoundRobinWriter{clients: clients}
+}
+
+// Write writes document to the next client in order.
+func (w *RoundRobinWriter) Write(data []byte) (int, error) {
+	if len(w.clients) == 0 {
+		return 0, errors.New("no clients")
+	}
+
+	i := (w.next.Add(1) - 1) % uint64(len(w.clients))
+	return w.clients[i].Write(data)
+}
+
+// Flush flushes all clients in parallel.
+func (w *RoundRobinWriter) Flush() error {
+	return w.each((*Client).Flush)
+}
+
+// Close closes all clients in parallel, waiting for all of them to finish.
+func (w *RoundRobinWriter) Close() error {
+	return w.each(func(c *Client) error {
+		if err := c.Close(); err != nil {
+			return err
+		}
+		<-c.doneCh
+		return c.closeErr
+	})
+}
+
+// each calls fn for every client in parallel and joins the errors.
+func (w *RoundRobinWriter) each(fn func(c *Client) error) error {
+	errs := make([]error, len(w.clients))
+
+	var wg sync.WaitGroup
+	for i, c := range w.clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = fn(c)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,72 @@
+//go:build s3
+
+package zincmetric
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Options configures ExportToS3.
+type S3Options struct {
+	// Client uploads the export, required.
+	Client *s3.Client
+	// PartSize is the size of multipart upload parts, exports smaller than
+	// a single part are uploaded at once. 0 means SDK default (5MiB).
+	PartSize int64
+	// Concurrency is the number of parts uploaded in parallel, 0 means SDK default.
+	Concurrency int
+}
+
+// ExportToS3 writes all documents of client's index as gzip compressed NDJSON to S3 object.
+// Returns the number of exported documents. Requires building with "s3" build tag.
+func (c *Client) ExportToS3(ctx context.Context, bucket, key string, opts S3Options) (int64, error) {
+	uploader := manager.NewUploader(opts.Client, func(u *manager.Uploader) {
+		if opts.PartSize > 0 {
+			u.PartSize = opts.PartSize
+		}
+		if opts.Concurrency > 0 {
+			u.Concurrency = opts.Concurrency
+		}
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	var exported int64
+	go func() {
+		zw := gzip.NewWriter(pw)
+		err := c.ScanAll(ctx, func(hit SearchHit) error {
+			if _, err := zw.Write(append(hit.Source, '\n')); err != nil {
+				return err
+			}
+			exported++
+			return nil
+		})
+		if cerr := zw.Close(); err == nil {
+			err = cerr
+		}
+		pw.CloseWithError(err)
+	}()
+
+	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String(key),
+		Body:            pr,
+		ContentType:     aws.String("application/x-ndjson"),
+		ContentEncoding: aws.String("gzip"),
+	})
+	if err != nil {
+		cancel()
+		pr.CloseWithError(err) // Unblock the scan.
+		return 0, err
+	}
+
+	return exported, nil
+}
@@ -0,0 +1,131 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// maxMappingVersions limits how many mapping versions ListMappingVersions returns.
+const maxMappingVersions = 1000
+
+// schemaHistoryIndex returns name of the index holding mapping versions of index recorded by SchemaTracker.
+func schemaHistoryIndex(index string) string {
+	return index + "_schema_history"
+}
+
+// MappingVersion is index mapping recorded by SchemaTracker.
+type MappingVersion struct {
+	Version    string
+	Index      string
+	Mapping    json.RawMessage
+	RecordedAt time.Time
+}
+
+// mappingVersionDoc is a mapping version document of the schema history index. Mapping is
+// stored as a string, so its fields aren't indexed as fields of the history index.
+type mappingVersionDoc struct {
+	Version    string    `json:"version"`
+	Index      string    `json:"index"`
+	Mapping    string    `json:"mapping"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// SchemaTracker keeps versioned history of client's index mapping in <index>_schema_history index.
+type SchemaTracker struct {
+	c *Client
+}
+
+// NewSchemaTracker creates schema tracker of the client's index.
+func NewSchemaTracker(client *Client) *SchemaTracker {
+	return &SchemaTracker{c: client}
+}
+
+// RecordMapping stores current index mapping tagged with the version.
+func (s *SchemaTracker) RecordMapping(ctx context.Context, version string) error {
+	mapping, err := s.c.GetMapping(ctx)
+	if err != nil {
+		return fmt.Errorf("get mapping: %w", err)
+	}
+
+	endpoint, err := s.c.endpoint("api", schemaHistoryIndex(s.c.currentIndex()), "_doc")
+	if err != nil {
+		return err
+	}
+
+	doc := mappingVersionDoc{
+		Version:    version,
+		Index:      s.c.currentIndex(),
+		Mapping:    string(mapping),
+		RecordedAt: time.Now().UTC(),
+	}
+
+	// Written without WithIngestPipeline pipeline, which is meant for client's documents.
+	return s.c.doJSON(ctx, http.MethodPost, endpoint, doc, nil)
+}
+
+// ListMappingVersions returns recorded mapping versions of the index, oldest first.
+func (s *SchemaTracker) ListMappingVersions(ctx context.Context) ([]MappingVersion, error) {
+	var qb QueryBuilder
+	return s.versions(ctx, qb.Term("index", s.c.currentIndex()))
+}
+
+// RollbackMapping re-applies the latest mapping recorded with the version.
+// ErrNotFound is returned if the version wasn't recorded.
+func (s *SchemaTracker) RollbackMapping(ctx context.Context, version string) error {
+	var qb QueryBuilder
+	versions, err := s.versions(ctx, qb.Bool().Filter(qb.Term("index", s.c.currentIndex()), qb.Term("version", version)))
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return fmt.Errorf("mapping version %q: %w", version, ErrNotFound)
+	}
+
+	return s.c.SetMapping(ctx, versions[len(versions)-1].Mapping)
+}
+
+// versions returns mapping versions matching the query, oldest first.
+func (s *SchemaTracker) versions(ctx context.Context, q Query) ([]MappingVersion, error) {
+	endpoint, err := s.c.endpoint("es", schemaHistoryIndex(s.c.currentIndex()), "_search")
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := q.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"query": query,
+		"sort":  []map[string]string{{"recorded_at": "asc"}},
+		"size":  maxMappingVersions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.c.search(ctx, s.c.readEndpoint(endpoint), body)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]MappingVersion, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		var doc mappingVersionDoc
+		if err := json.Unmarshal(hit.Source, &doc); err != nil {
+			return nil, err
+		}
+		versions = append(versions, MappingVersion{
+			Version:    doc.Version,
+			Index:      doc.Index,
+			Mapping:    json.RawMessage(doc.Mapping),
+			RecordedAt: doc.RecordedAt,
+		})
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,107 @@
+package zincmetric
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var (
+	// functionScoreModes are allowed function score query score modes.
+	functionScoreModes = map[string]bool{"multiply": true, "sum": true, "avg": true, "first": true, "max": true, "min": true}
+	// functionBoostModes are allowed function score query boost modes.
+	functionBoostModes = map[string]bool{"multiply": true, "replace": true, "sum": true, "avg": true, "max": true, "min": true}
+	// decayFunctions are allowed decay function types.
+	decayFunctions = map[string]bool{"gauss": true, "exp": true, "linear": true}
+)
+
+// ScoreFunction modifies score of documents matched by function score query.
+type ScoreFunction struct {
+	// Filter limits documents the function is applied to, nil means all.
+	Filter Query
+	// Weight multiplies function score, 0 means no weight.
+	Weight float64
+
+	name string // function name, empty for weight only functions
+	body any
+	err  error
+}
+
+// WeightFunction multiplies document score by weight.
+func WeightFunction(weight float64) ScoreFunction {
+	return ScoreFunction{Weight: weight}
+}
+
+// FieldValueFunction uses value of the numeric field as document score.
+func FieldValueFunction(field string) ScoreFunction {
+	return ScoreFunction{name: "field_value_factor", body: map[string]string{"field": field}}
+}
+
+// RandomScoreFunction scores documents randomly, the same seed gives the same scores.
+func RandomScoreFunction(seed int) ScoreFunction {
+	return ScoreFunction{name: "random_score", body: map[string]any{"seed": seed, "field": "_seq_no"}}
+}
+
+// DecayParams configure decay function. Origin, Scale and Offset use field units,
+// e.g. "now" and "7d" for dates or "52.3,4.9" and "2km" for geo points.
+type DecayParams struct {
+	Origin any     `json:"origin,omitempty"`
+	Scale  string  `json:"scale"`
+	Offset string  `json:"offset,omitempty"`
+	Decay  float64 `json:"decay,omitempty"`
+}
+
+// DecayFunction scores documents by distance of field value from params origin,
+// kind is one of "gauss", "exp" or "linear".
+func DecayFunction(kind, field string, params DecayParams) ScoreFunction {
+	var err error
+	if !decayFunctions[kind] {
+		err = fmt.Errorf("invalid decay function %q", kind)
+	}
+
+	return ScoreFunction{name: kind, body: map[string]DecayParams{field: params}, err: err}
+}
+
+func (f ScoreFunction) MarshalJSON() ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	out := make(map[string]any, 3)
+	if f.name != "" {
+		out[f.name] = f.body
+	}
+	if f.Weight != 0 {
+		out["weight"] = f.Weight
+	}
+	if f.Filter != nil {
+		out["filter"] = queryJSON{f.Filter}
+	}
+
+	return json.Marshal(out)
+}
+
+// FunctionScore modifies scores of documents matched by query using functions.
+// scoreMode combines function scores and boostMode combines the result with query score,
+// empty modes mean ZincSearch defaults.
+func (QueryBuilder) FunctionScore(query Query, functions []ScoreFunction, scoreMode, boostMode string) Query {
+	var err error
+	switch {
+	case scoreMode != "" && !functionScoreModes[scoreMode]:
+		err = fmt.Errorf("invalid function score mode %q", scoreMode)
+	case boostMode != "" && !functionBoostModes[boostMode]:
+		err = fmt.Errorf("invalid function boost mode %q", boostMode)
+	}
+
+	body := map[string]any{"functions": functions}
+	if query != nil {
+		body["query"] = queryJSON{query}
+	}
+	if scoreMode != "" {
+		body["score_mode"] = scoreMode
+	}
+	if boostMode != "" {
+		body["boost_mode"] = boostMode
+	}
+
+	return clause{name: "function_score", body: body, err: err}
+}
@@ -0,0 +1,238 @@
+package zincmetric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// defaultScrollKeepAlive is how long scroll context is kept between pages.
+	defaultScrollKeepAlive = time.Minute
+	// defaultScanSize is page size used by ScanAll.
+	defaultScanSize = 1000
+)
+
+// Scroller pages through all search results. Point-in-time with search_after
+// pagination is preferred, falling back to scroll API if PIT can't be opened.
+type Scroller struct {
+	c         *Client
+	query     json.RawMessage
+	keepAlive time.Duration
+
+	started   bool
+	pitID     string
+	sortAfter []json.RawMessage
+	scrollID  string
+	done      bool
+}
+
+// Scroll creates a scroller going through all results of query,
+// keeping search context alive for keepAlive between pages.
+// Page size is controlled by query "size" key.
+func (c *Client) Scroll(query json.RawMessage, keepAlive time.Duration) *Scroller {
+	if keepAlive <= 0 {
+		keepAlive = defaultScrollKeepAlive
+	}
+
+	return &Scroller{c: c, query: query, keepAlive: keepAlive}
+}
+
+// Next returns next page of results, io.EOF is returned once all results were read.
+func (s *Scroller) Next(ctx context.Context) (*SearchResult, error) {
+	if s.done {
+		return nil, io.EOF
+	}
+
+	if !s.started {
+		pitID, err := s.c.OpenPIT(ctx, s.keepAlive)
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+		s.pitID = pitID // Empty on error, scroll API is used then.
+		s.started = true
+	}
+
+	var (
+		res *SearchResult
+		err error
+	)
+	switch {
+	case s.pitID != "":
+		res, err = s.nextPIT(ctx)
+	case s.scrollID == "":
+		res, err = s.first(ctx)
+	default:
+		res, err = s.next(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Hits) == 0 {
+		s.done = true
+		return nil, io.EOF
+	}
+
+	return res, nil
+}
+
+// nextPIT fetches next page using point-in-time and search_after.
+func (s *Scroller) nextPIT(ctx context.Context) (*SearchResult, error) {
+	req, err := newSearchRequest(s.query, []SearchOption{
+		PITOption(s.pitID, s.keepAlive),
+		withDefaultSort(defaultPITSort),
+		searchAfter(s.sortAfter),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, body, err := req.encode(s.c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pages are fetched bypassing query cache.
+	res, err := s.c.search(ctx, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.PITID != "" {
+		s.pitID = res.PITID
+	}
+	if len(res.Hits) > 0 {
+		s.sortAfter = res.Hits[len(res.Hits)-1].Sort
+	}
+
+	return res, nil
+}
+
+// first starts scroll search, following pages are fetched by scroll ID and keep its query.
+func (s *Scroller) first(ctx context.Context) (*SearchResult, error) {
+	req, err := newSearchRequest(s.query, []SearchOption{func(r *searchRequest) error {
+		r.params.Set("scroll", esDuration(s.keepAlive))
+		return nil
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, body, err := req.encode(s.c)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.c.search(ctx, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	s.scrollID = res.ScrollID
+	return res, nil
+}
+
+// next fetches next scroll page.
+func (s *Scroller) next(ctx context.Context) (*SearchResult, error) {
+	endpoint, err := s.c.endpoint("es", "_search", "scroll")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"scroll":    esDuration(s.keepAlive),
+		"scroll_id": s.scrollID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.c.search(ctx, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	s.scrollID = res.ScrollID
+	return res, nil
+}
+
+// Close releases search context.
+func (s *Scroller) Close(ctx context.Context) error {
+	s.done = true
+	if s.pitID != "" {
+		pitID := s.pitID
+		s.pitID = ""
+		return s.c.ClosePIT(ctx, pitID)
+	}
+	if s.scrollID == "" {
+		return nil
+	}
+
+	endpoint, err := s.c.endpoint("es", "_search", "scroll")
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]string{"scroll_id": s.scrollID})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.c.do(ctx, http.MethodDelete, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	s.scrollID = ""
+
+	return resp.Body.Close()
+}
+
+// ScanAll calls fn for every document in the index. Scanning stops on the first
+// error returned by fn, which is then returned from ScanAll.
+func (c *Client) ScanAll(ctx context.Context, fn func(hit SearchHit) error) error {
+	return c.scan(ctx, defaultScanSize, fn)
+}
+
+// scan is ScanAll with configurable page size.
+func (c *Client) scan(ctx context.Context, size int, fn func(hit SearchHit) error) error {
+	query, err := json.Marshal(map[string]any{
+		"size":  size,
+		"query": map[string]any{"match_all": struct{}{}},
+	})
+	if err != nil {
+		return err
+	}
+
+	s := c.Scroll(query, defaultScrollKeepAlive)
+	defer s.Close(context.WithoutCancel(ctx))
+
+	for {
+		page, err := s.Next(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, hit := range page.Hits {
+			if err := fn(hit); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// esDuration formats duration using Elasticsearch time units.
+func esDuration(d time.Duration) string {
+	if d%time.Second != 0 {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+
+	return fmt.Sprintf("%ds", int64(d.Seconds()))
+}
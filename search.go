@@ -0,0 +1,354 @@
+package zincmetric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// SearchResult is a ZincSearch (Elasticsearch compatible) search response.
+type SearchResult struct {
+	Took     int64
+	TimedOut bool
+	Total    int64
+	MaxScore float64
+	Hits     []SearchHit
+
+	// Aggregations holds raw aggregation results keyed by aggregation name.
+	Aggregations json.RawMessage
+	// Suggest holds raw suggester results keyed by suggestion name.
+	Suggest json.RawMessage
+	// ScrollID is set when search was started with scroll enabled.
+	ScrollID string
+	// PITID is set for point-in-time searches, it should be used for the following searches.
+	PITID string
+}
+
+// SearchHit is a single document matched by search.
+type SearchHit struct {
+	Index  string            `json:"_index"`
+	ID     string            `json:"_id"`
+	Score  float64           `json:"_score"`
+	Source json.RawMessage   `json:"_source"`
+	Sort   []json.RawMessage `json:"sort,omitempty"`
+
+	// Highlight holds highlighted fragments by field, see SearchWithHighlight.
+	Highlight map[string][]string `json:"highlight,omitempty"`
+	// InnerHits holds hits of collapsed groups, keyed by inner hits name.
+	InnerHits map[string]InnerHits `json:"inner_hits,omitempty"`
+	// MatchedQueries holds names of matched queries, see QueryBuilder.Named.
+	MatchedQueries []string `json:"matched_queries,omitempty"`
+}
+
+// InnerHits are hits grouped under a single search hit.
+type InnerHits struct {
+	Total int64
+	Hits  []SearchHit
+}
+
+func (h *InnerHits) UnmarshalJSON(b []byte) error {
+	var wire struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []SearchHit `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(b, &wire); err != nil {
+		return err
+	}
+
+	h.Total = wire.Hits.Total.Value
+	h.Hits = wire.Hits.Hits
+	return nil
+}
+
+// searchResponse is the wire format of search response.
+type searchResponse struct {
+	Took     int64  `json:"took"`
+	TimedOut bool   `json:"timed_out"`
+	ScrollID string `json:"_scroll_id"`
+	PITID    string `json:"pit_id"`
+	Hits     struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		MaxScore float64     `json:"max_score"`
+		Hits     []SearchHit `json:"hits"`
+	} `json:"hits"`
+	Aggregations json.RawMessage `json:"aggregations"`
+	Suggest      json.RawMessage `json:"suggest"`
+}
+
+func (r *searchResponse) result() *SearchResult {
+	return &SearchResult{
+		Took:         r.Took,
+		TimedOut:     r.TimedOut,
+		Total:        r.Hits.Total.Value,
+		MaxScore:     r.Hits.MaxScore,
+		Hits:         r.Hits.Hits,
+		Aggregations: r.Aggregations,
+		Suggest:      r.Suggest,
+		ScrollID:     r.ScrollID,
+		PITID:        r.PITID,
+	}
+}
+
+// Search runs query against client's index. Query is the full search
+// request body (query, size, sort, etc.), nil query matches all documents.
+// Options can modify the request, e.g. PITOption.
+func (c *Client) Search(ctx context.Context, query json.RawMessage, opts ...SearchOption) (*SearchResult, error) {
+	req, err := newSearchRequest(query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, body, err := req.encode(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.maxQueryCost != "" {
+		if err := c.checkQueryCost(body); err != nil {
+			return nil, err
+		}
+	}
+
+	cacheKey := append([]byte(endpoint), body...)
+	if c.queryCache != nil {
+		if res, ok := c.queryCache.get(cacheKey); ok {
+			return res, nil
+		}
+	}
+
+	res, err := c.search(ctx, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.queryCache != nil {
+		c.queryCache.put(cacheKey, res)
+	}
+
+	return res, nil
+}
+
+// search posts search request body to the given search endpoint.
+func (c *Client) search(ctx context.Context, endpoint string, query json.RawMessage) (*SearchResult, error) {
+	if len(query) == 0 {
+		query = json.RawMessage(`{}`)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, endpoint, bytes.NewReader(query))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var sr searchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&sr); err != nil {
+		return nil, err
+	}
+
+	return sr.result(), nil
+}
+
+// SearchWithSourceFilter runs query returning only included source fields without excluded ones.
+// If both includes and excludes are empty, whole documents are returned.
+func (c *Client) SearchWithSourceFilter(ctx context.Context, query json.RawMessage, includes, excludes []string) (*SearchResult, error) {
+	return c.Search(ctx, query, SourceFilter(includes, excludes))
+}
+
+// SearchWithCollapse runs query returning only the top hit of every collapseField value.
+// If innerHits is set, other hits of the group are returned in hit InnerHits.
+func (c *Client) SearchWithCollapse(ctx context.Context, query json.RawMessage, collapseField string, innerHits *InnerHitsConfig) (*SearchResult, error) {
+	return c.Search(ctx, query, Collapse(collapseField, innerHits))
+}
+
+// SearchWithRescore runs query, rescoring its top windowSize hits using rescore query.
+func (c *Client) SearchWithRescore(ctx context.Context, query json.RawMessage, rescore RescoreQuery, windowSize int) (*SearchResult, error) {
+	return c.Search(ctx, query, Rescore(rescore, windowSize))
+}
+
+// SearchAcrossIndexes runs query against all indexes at once, SearchHit.Index tells the index of every hit.
+func (c *Client) SearchAcrossIndexes(ctx context.Context, indexes []string, query json.RawMessage) (*SearchResult, error) {
+	if len(indexes) == 0 {
+		return nil, errors.New("no indexes to search")
+	}
+
+	escaped := make([]string, len(indexes))
+	for i, index := range indexes {
+		escaped[i] = url.PathEscape(index)
+	}
+
+	endpoint, err := c.endpoint("es", strings.Join(escaped, ","), "_search")
+	if err != nil {
+		return nil, err
+	}
+
+	if query, err = c.filterSoftDeleted(query); err != nil {
+		return nil, err
+	}
+
+	return c.search(ctx, c.readEndpoint(endpoint), query)
+}
+
+// SearchOnShard runs query only on shards holding documents with routingKey.
+func (c *Client) SearchOnShard(ctx context.Context, query json.RawMessage, routingKey string) (*SearchResult, error) {
+	return c.Search(ctx, query, Routing(routingKey))
+}
+
+// SearchWithHighlight runs query returning highlighted matches in hit Highlight.
+func (c *Client) SearchWithHighlight(ctx context.Context, query json.RawMessage, highlight HighlightConfig) (*SearchResult, error) {
+	return c.Search(ctx, query, Highlight(highlight))
+}
+
+// multiSearchResponse is multi search response.
+type multiSearchResponse struct {
+	Responses []struct {
+		searchResponse
+		Error json.RawMessage `json:"error"`
+	} `json:"responses"`
+}
+
+// MultiSearch runs multiple queries (full request bodies) in a single request,
+// results are returned in the same order as queries.
+func (c *Client) MultiSearch(ctx context.Context, queries []json.RawMessage) ([]*SearchResult, error) {
+	endpoint, err := c.endpoint("es", "_msearch")
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := json.Marshal(map[string]string{"index": c.currentIndex()})
+	if err != nil {
+		return nil, err
+	}
+
+	// Body is NDJSON of header and query pairs.
+	body := new(bytes.Buffer)
+	for _, q := range queries {
+		if len(q) == 0 {
+			q = json.RawMessage(`{}`)
+		}
+		if q, err = c.filterSoftDeleted(q); err != nil {
+			return nil, err
+		}
+		compact := new(bytes.Buffer)
+		if err := json.Compact(compact, q); err != nil {
+			return nil, err
+		}
+
+		body.Write(header)
+		body.WriteByte('\n')
+		body.Write(compact.Bytes())
+		body.WriteByte('\n')
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var msr multiSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&msr); err != nil {
+		return nil, err
+	}
+
+	results := make([]*SearchResult, 0, len(msr.Responses))
+	for i, r := range msr.Responses {
+		if len(r.Error) > 0 && string(r.Error) != "null" {
+			return nil, fmt.Errorf("query %d failed: %s", i, r.Error)
+		}
+		results = append(results, r.result())
+	}
+
+	return results, nil
+}
+
+// Suggest runs suggesters (the "suggest" section of search request) and returns raw suggestions.
+func (c *Client) Suggest(ctx context.Context, suggest json.RawMessage) (json.RawMessage, error) {
+	query, err := json.Marshal(map[string]any{"size": 0, "suggest": suggest})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Suggest, nil
+}
+
+// ForEach calls fn with source of every hit, stopping once fn returns false.
+func (r *SearchResult) ForEach(fn func(index int, hit json.RawMessage) bool) {
+	for i, hit := range r.Hits {
+		if !fn(i, hit.Source) {
+			return
+		}
+	}
+}
+
+// Unmarshal unmarshals source of the first hit into out, ErrNotFound is returned if there are no hits.
+func (r *SearchResult) Unmarshal(out any) error {
+	if len(r.Hits) == 0 {
+		return ErrNotFound
+	}
+
+	return json.Unmarshal(r.Hits[0].Source, out)
+}
+
+// UnmarshalAll unmarshals sources of all hits into out, which must be a pointer to slice.
+func (r *SearchResult) UnmarshalAll(out any) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("expected pointer to slice, got %T", out)
+	}
+
+	slice := reflect.MakeSlice(v.Elem().Type(), len(r.Hits), len(r.Hits))
+	for i, hit := range r.Hits {
+		if err := json.Unmarshal(hit.Source, slice.Index(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("hit %d: %w", i, err)
+		}
+	}
+	v.Elem().Set(slice)
+
+	return nil
+}
+
+// SearchAs runs query and unmarshals source of every hit into T.
+// Returns the hits together with the total number of matching documents.
+func SearchAs[T any](ctx context.Context, client *Client, query json.RawMessage, opts ...SearchOption) ([]T, int64, error) {
+	res, err := client.Search(ctx, query, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var hits []T
+	if err := res.UnmarshalAll(&hits); err != nil {
+		return nil, 0, err
+	}
+
+	return hits, res.Total, nil
+}
+
+// SearchOneAs runs query and unmarshals source of the first hit into T, ErrNotFound is returned if there are no hits.
+func SearchOneAs[T any](ctx context.Context, client *Client, query json.RawMessage, opts ...SearchOption) (T, error) {
+	var hit T
+
+	res, err := client.Search(ctx, query, opts...)
+	if err != nil {
+		return hit, err
+	}
+
+	err = res.Unmarshal(&hit)
+	return hit, err
+}
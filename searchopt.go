@@ -0,0 +1,175 @@
+package zincmetric
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// SearchOption modifies search request before it's sent.
+type SearchOption func(r *searchRequest) error
+
+// searchRequest is a search request being built.
+type searchRequest struct {
+	body   map[string]json.RawMessage
+	params url.Values
+
+	// indexless requests are sent without index in the path (required by PIT searches).
+	indexless bool
+}
+
+// newSearchRequest parses query and applies search options to it.
+func newSearchRequest(query json.RawMessage, opts []SearchOption) (*searchRequest, error) {
+	r := &searchRequest{
+		body:   make(map[string]json.RawMessage),
+		params: make(url.Values),
+	}
+
+	if len(query) > 0 {
+		if err := json.Unmarshal(query, &r.body); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// set sets search request body key.
+func (r *searchRequest) set(key string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	r.body[key] = b
+	return nil
+}
+
+// encode builds search request URL and body.
+func (r *searchRequest) encode(c *Client) (string, []byte, error) {
+	elem := []string{"es", c.currentIndex(), "_search"}
+	if r.indexless {
+		elem = []string{"es", "_search"}
+	}
+
+	endpoint, err := c.endpoint(elem...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	params := r.params
+	if c.preferLocal {
+		params.Set("preference", "_local")
+	}
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	if c.softDeleteFilter {
+		if err := excludeSoftDeleted(r.body); err != nil {
+			return "", nil, err
+		}
+	}
+
+	body, err := json.Marshal(r.body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return endpoint, body, nil
+}
+
+// SourceFilter limits returned document fields, empty includes and excludes leave source unfiltered.
+func SourceFilter(includes, excludes []string) SearchOption {
+	return func(r *searchRequest) error {
+		if len(includes) == 0 && len(excludes) == 0 {
+			return nil
+		}
+
+		filter := make(map[string][]string, 2)
+		if len(includes) > 0 {
+			filter["includes"] = includes
+		}
+		if len(excludes) > 0 {
+			filter["excludes"] = excludes
+		}
+
+		return r.set("_source", filter)
+	}
+}
+
+// Routing limits search to shards holding documents with routing key.
+func Routing(key string) SearchOption {
+	return func(r *searchRequest) error {
+		r.params.Set("routing", key)
+		return nil
+	}
+}
+
+// InnerHitsConfig configures hits returned for every collapsed group.
+type InnerHitsConfig struct {
+	Name string            `json:"name"`
+	Size int               `json:"size,omitempty"`
+	Sort []json.RawMessage `json:"sort,omitempty"`
+}
+
+// Collapse collapses search results by field value, keeping the top hit of every group.
+func Collapse(field string, innerHits *InnerHitsConfig) SearchOption {
+	return func(r *searchRequest) error {
+		collapse := map[string]any{"field": field}
+		if innerHits != nil {
+			collapse["inner_hits"] = innerHits
+		}
+
+		return r.set("collapse", collapse)
+	}
+}
+
+// RescoreQuery is a secondary query rescoring top hits of the main query.
+type RescoreQuery struct {
+	// Query is the rescore query clause, e.g. {"match_phrase": {...}}.
+	Query json.RawMessage `json:"rescore_query"`
+	// QueryWeight and RescoreQueryWeight weigh main and rescore query scores, 0 means ZincSearch default.
+	QueryWeight        float64 `json:"query_weight,omitempty"`
+	RescoreQueryWeight float64 `json:"rescore_query_weight,omitempty"`
+}
+
+// Rescore rescores top windowSize hits using rescore query, non positive windowSize means ZincSearch default.
+func Rescore(rescore RescoreQuery, windowSize int) SearchOption {
+	return func(r *searchRequest) error {
+		body := map[string]any{"query": rescore}
+		if windowSize > 0 {
+			body["window_size"] = windowSize
+		}
+
+		return r.set("rescore", body)
+	}
+}
+
+// HighlightConfig configures highlighting of matches, zero values mean ZincSearch defaults.
+type HighlightConfig struct {
+	Fields            map[string]HighlightField `json:"fields"`
+	PreTags           []string                  `json:"pre_tags,omitempty"`
+	PostTags          []string                  `json:"post_tags,omitempty"`
+	FragmentSize      int                       `json:"fragment_size,omitempty"`
+	NumberOfFragments int                       `json:"number_of_fragments,omitempty"`
+	Encoder           string                    `json:"encoder,omitempty"` // "default" or "html"
+}
+
+// HighlightField overrides HighlightConfig for a single field.
+type HighlightField struct {
+	FragmentSize      int `json:"fragment_size,omitempty"`
+	NumberOfFragments int `json:"number_of_fragments,omitempty"`
+}
+
+// Highlight returns highlighted matches of the configured fields in hit Highlight.
+func Highlight(highlight HighlightConfig) SearchOption {
+	return func(r *searchRequest) error {
+		return r.set("highlight", highlight)
+	}
+}
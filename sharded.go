@@ -0,0 +1,156 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sync"
+)
+
+// defaultSearchSize is Elasticsearch default number of hits returned by search.
+const defaultSearchSize = 10
+
+// ShardedClient spreads documents across multiple virtual indexes (shards),
+// which are in turn spread across ZincSearch nodes.
+type ShardedClient struct {
+	shards []*Client
+}
+
+// NewShardedClient creates shards clients for indexes index_0 to index_N-1,
+// shard i is placed on node i % len(nodes).
+func NewShardedClient(nodes []string, user, pass, index string, shards int, opts ...OptionFunc) (*ShardedClient, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("no nodes given")
+	}
+	if shards <= 0 {
+		return nil, fmt.Errorf("invalid shard count: %d", shards)
+	}
+
+	s := &ShardedClient{shards: make([]*Client, 0, shards)}
+	for i := range shards {
+		c, err := New(nodes[i%len(nodes)], user, pass, fmt.Sprintf("%s_%d", index, i), opts...)
+		if err != nil {
+			return nil, errors.Join(err, s.Close())
+		}
+		s.shards = append(s.shards, c)
+	}
+
+	return s, nil
+}
+
+// Write writes document to the shard selected by fnv32 hash of document "_id" field.
+// Documents without ID are routed by hash of the whole document.
+func (s *ShardedClient) Write(data []byte) (int, error) {
+	return s.shards[s.shardOf(data)].Write(data)
+}
+
+// shardOf returns shard index of the document.
+func (s *ShardedClient) shardOf(data []byte) int {
+	var doc struct {
+		ID string `json:"_id"`
+	}
+
+	h := fnv.New32a()
+	if err := json.Unmarshal(data, &doc); err == nil && doc.ID != "" {
+		h.Write([]byte(doc.ID))
+	} else {
+		h.Write(data)
+	}
+
+	return int(h.Sum32() % uint32(len(s.shards)))
+}
+
+// Search runs query on all shards and merges results by score, keeping the number of hits
+// requested by query "size" key, starting at "from" hit. Aggregations and sort order can't
+// be merged across shards, so queries with aggregations or sort are rejected.
+func (s *ShardedClient) Search(ctx context.Context, query json.RawMessage, opts ...SearchOption) (*SearchResult, error) {
+	req, err := newSearchRequest(query, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range []string{"aggs", "aggregations"} {
+		if _, ok := req.body[key]; ok {
+			return nil, errors.New("sharded search doesn't support aggregations")
+		}
+	}
+	if _, ok := req.body["sort"]; ok {
+		return nil, errors.New("sharded search doesn't support sorted queries")
+	}
+
+	from, size := searchWindow(req.body)
+	// Every shard returns hits from the beginning, as any of them may hold hits of the requested page.
+	window := func(r *searchRequest) error {
+		delete(r.body, "from")
+		return r.set("size", from+size)
+	}
+	opts = append(slices.Clip(opts), window)
+
+	results := make([]*SearchResult, len(s.shards))
+	errs := make([]error, len(s.shards))
+
+	var wg sync.WaitGroup
+	for i, shard := range s.shards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = shard.Search(ctx, query, opts...)
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	merged := &SearchResult{}
+	for _, res := range results {
+		merged.Took = max(merged.Took, res.Took)
+		merged.TimedOut = merged.TimedOut || res.TimedOut
+		merged.Total += res.Total
+		merged.MaxScore = max(merged.MaxScore, res.MaxScore)
+		merged.Hits = append(merged.Hits, res.Hits...)
+	}
+
+	slices.SortStableFunc(merged.Hits, func(a, b SearchHit) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+
+	merged.Hits = merged.Hits[min(from, len(merged.Hits)):]
+	if len(merged.Hits) > size {
+		merged.Hits = merged.Hits[:size]
+	}
+
+	return merged, nil
+}
+
+// Close closes all shard clients.
+func (s *ShardedClient) Close() error {
+	errs := make([]error, 0, len(s.shards))
+	for _, c := range s.shards {
+		errs = append(errs, c.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// searchWindow returns "from" and "size" of search request body.
+func searchWindow(body map[string]json.RawMessage) (from, size int) {
+	size = defaultSearchSize
+	if b, ok := body["from"]; ok {
+		_ = json.Unmarshal(b, &from)
+	}
+	if b, ok := body["size"]; ok {
+		_ = json.Unmarshal(b, &size)
+	}
+
+	return max(from, 0), max(size, 0)
+}
@@ -0,0 +1,53 @@
+package zincmetric
+
+import "encoding/json"
+
+// SignificantTerms returns up to size field terms occurring in matched documents unusually
+// often compared to the whole index, terms in less than minDocCount matched documents are
+// skipped (0 uses the server default). Results decode into SignificantTermsResult.
+func (AggregationBuilder) SignificantTerms(name, field string, size int, minDocCount int) Aggregation {
+	body := map[string]any{"field": field}
+	if size > 0 {
+		body["size"] = size
+	}
+	if minDocCount > 0 {
+		body["min_doc_count"] = minDocCount
+	}
+
+	return aggregation{name: name, typ: "significant_terms", body: body}
+}
+
+// SignificantTermsResult is the result of SignificantTerms aggregation.
+type SignificantTermsResult struct {
+	// DocCount is the number of matched documents.
+	DocCount int64 `json:"doc_count"`
+	// BgCount is the number of documents in the background set (the whole index).
+	BgCount int64                    `json:"bg_count"`
+	Buckets []SignificantTermsBucket `json:"buckets"`
+}
+
+// SignificantTermsBucket is a significant term, Score tells how unusual its frequency is.
+type SignificantTermsBucket struct {
+	Key string
+	// DocCount is the number of matched documents containing the term.
+	DocCount int64
+	Score    float64
+	// BgCount is the number of background documents containing the term.
+	BgCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (b *SignificantTermsBucket) UnmarshalJSON(data []byte) error {
+	var key json.RawMessage
+	subs, err := splitBucket(data, map[string]any{
+		"key": &key, "doc_count": &b.DocCount, "score": &b.Score, "bg_count": &b.BgCount,
+	})
+	if err != nil {
+		return err
+	}
+
+	b.Key = bucketKey(key)
+	b.Aggregations = subs
+	return nil
+}
@@ -0,0 +1,171 @@
+package zincmetric
+
+import "errors"
+
+// withSub adds sub aggregation to agg if it's set.
+func withSub(agg Aggregation, sub Aggregation) Aggregation {
+	if sub == nil {
+		return agg
+	}
+
+	return agg.Sub(sub)
+}
+
+// Sampler runs sub aggregation only on the top shardSize best scoring documents of every shard
+// (0 uses the server default of 100), trading accuracy for speed on large indexes.
+// Results decode into SamplerAggResult.
+func (AggregationBuilder) Sampler(name string, shardSize int, sub Aggregation) Aggregation {
+	body := make(map[string]any, 1)
+	if shardSize > 0 {
+		body["shard_size"] = shardSize
+	}
+
+	return withSub(aggregation{name: name, typ: "sampler", body: body}, sub)
+}
+
+// SamplerAggResult is the result of Sampler aggregation.
+type SamplerAggResult struct {
+	// DocCount is the number of sampled documents.
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (r *SamplerAggResult) UnmarshalJSON(data []byte) error {
+	var err error
+	r.Aggregations, err = splitBucket(data, map[string]any{"doc_count": &r.DocCount})
+	return err
+}
+
+// Missing counts documents having no field value (field is missing or null, empty strings count
+// as values). Results decode into MissingAggResult.
+func (AggregationBuilder) Missing(name, field string) Aggregation {
+	return fieldAggregation(name, "missing", field)
+}
+
+// MissingAggResult is the result of Missing aggregation.
+type MissingAggResult struct {
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (r *MissingAggResult) UnmarshalJSON(data []byte) error {
+	var err error
+	r.Aggregations, err = splitBucket(data, map[string]any{"doc_count": &r.DocCount})
+	return err
+}
+
+// Filter runs sub aggregation on documents matching filter query. Results decode into FilterAggResult.
+func (AggregationBuilder) Filter(name string, filter Query, sub Aggregation) Aggregation {
+	if filter == nil {
+		return aggregation{name: name, err: errors.New("filter aggregation has no filter")}
+	}
+
+	return withSub(aggregation{name: name, typ: "filter", body: queryJSON{filter}}, sub)
+}
+
+// FilterAggResult is the result of Filter aggregation.
+type FilterAggResult struct {
+	// DocCount is the number of documents matching the filter.
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (r *FilterAggResult) UnmarshalJSON(data []byte) error {
+	var err error
+	r.Aggregations, err = splitBucket(data, map[string]any{"doc_count": &r.DocCount})
+	return err
+}
+
+// Filters groups documents into a bucket per named filter query, running sub aggregation on each.
+// Documents can fall into multiple buckets. Results decode into FiltersAggResult.
+func (AggregationBuilder) Filters(name string, filters map[string]Query, sub Aggregation) Aggregation {
+	if len(filters) == 0 {
+		return aggregation{name: name, err: errors.New("filters aggregation has no filters")}
+	}
+
+	return withSub(aggregation{name: name, typ: "filters", body: map[string]any{"filters": namedQueriesJSON(filters)}}, sub)
+}
+
+// FiltersAggResult is the result of Filters aggregation.
+type FiltersAggResult struct {
+	// Buckets are keyed by filter name.
+	Buckets map[string]FilterBucket `json:"buckets"`
+}
+
+// FilterBucket holds documents matching a filter of Filters aggregation.
+type FilterBucket struct {
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (b *FilterBucket) UnmarshalJSON(data []byte) error {
+	var err error
+	b.Aggregations, err = splitBucket(data, map[string]any{"doc_count": &b.DocCount})
+	return err
+}
+
+// Global runs sub aggregation on all index documents, ignoring the search query, e.g. to compare
+// query buckets with index totals. Global must be a top level aggregation.
+// Results decode into GlobalAggResult.
+func (AggregationBuilder) Global(name string, sub Aggregation) Aggregation {
+	return withSub(aggregation{name: name, typ: "global", body: struct{}{}}, sub)
+}
+
+// GlobalAggResult is the result of Global aggregation.
+type GlobalAggResult struct {
+	// DocCount is the number of index documents.
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (r *GlobalAggResult) UnmarshalJSON(data []byte) error {
+	var err error
+	r.Aggregations, err = splitBucket(data, map[string]any{"doc_count": &r.DocCount})
+	return err
+}
+
+// Nested runs sub aggregation on nested objects at path of matched documents.
+// Results decode into NestedAggResult.
+func (AggregationBuilder) Nested(name, path string, sub Aggregation) Aggregation {
+	return withSub(aggregation{name: name, typ: "nested", body: map[string]string{"path": path}}, sub)
+}
+
+// NestedAggResult is the result of Nested aggregation.
+type NestedAggResult struct {
+	// DocCount is the number of nested objects.
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (r *NestedAggResult) UnmarshalJSON(data []byte) error {
+	var err error
+	r.Aggregations, err = splitBucket(data, map[string]any{"doc_count": &r.DocCount})
+	return err
+}
+
+// ReverseNested runs sub aggregation on parent documents of nested objects, it's used
+// inside Nested aggregation, e.g. to count documents per nested object value.
+// Results decode into ReverseNestedAggResult.
+func (AggregationBuilder) ReverseNested(name string, sub Aggregation) Aggregation {
+	return withSub(aggregation{name: name, typ: "reverse_nested", body: struct{}{}}, sub)
+}
+
+// ReverseNestedAggResult is the result of ReverseNested aggregation.
+type ReverseNestedAggResult struct {
+	// DocCount is the number of parent documents.
+	DocCount int64
+	// Aggregations holds sub aggregation results.
+	Aggregations AggregationResults
+}
+
+func (r *ReverseNestedAggResult) UnmarshalJSON(data []byte) error {
+	var err error
+	r.Aggregations, err = splitBucket(data, map[string]any{"doc_count": &r.DocCount})
+	return err
+}
@@ -0,0 +1,38 @@
+package zincmetric
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Sink discards all written documents. It can stand in for Client
+// when ZincSearch writes need to be disabled without changing call sites.
+type Sink struct {
+	onDiscard func(doc json.RawMessage)
+}
+
+var _ ClientInterface = (*Sink)(nil)
+
+// NewSink creates Sink, onDiscard (if not nil) is called with every discarded document.
+func NewSink(onDiscard func(doc json.RawMessage)) *Sink {
+	return &Sink{onDiscard: onDiscard}
+}
+
+// Write discards the document.
+func (s *Sink) Write(data []byte) (int, error) {
+	if s.onDiscard != nil {
+		s.onDiscard(bytes.Clone(data))
+	}
+
+	return len(data), nil
+}
+
+// Close does nothing.
+func (s *Sink) Close() error {
+	return nil
+}
+
+// Stats returns zero Stats, nothing is ever sent.
+func (s *Sink) Stats() Stats {
+	return Stats{}
+}
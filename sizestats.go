@@ -0,0 +1,73 @@
+package zincmetric
+
+import (
+	"math"
+	"slices"
+)
+
+// defaultSizePercentiles are used by WithSizeTracking when no percentiles are given.
+var defaultSizePercentiles = []float64{50, 90, 99}
+
+// SizeStats describes document size distribution (in bytes)
+// of the last batch flushed to ZincSearch service.
+type SizeStats struct {
+	Count int
+	Min   int
+	Max   int
+	Mean  float64
+
+	// Percentiles maps requested percentile (e.g. 99) to document size.
+	Percentiles map[float64]int
+}
+
+// SizeStats returns document size statistics of the last flushed batch.
+// Zero value is returned if size tracking is not enabled using WithSizeTracking.
+func (c *Client) SizeStats() SizeStats {
+	c.sizeMu.Lock()
+	defer c.sizeMu.Unlock()
+
+	stats := c.sizeStats
+	stats.Percentiles = make(map[float64]int, len(c.sizeStats.Percentiles))
+	for p, v := range c.sizeStats.Percentiles {
+		stats.Percentiles[p] = v
+	}
+
+	return stats
+}
+
+// recordSizes computes size statistics of the flushed batch.
+func (c *Client) recordSizes(buff [][]byte) {
+	if !c.sizeTracking || len(buff) == 0 {
+		return
+	}
+
+	sizes := make([]int, len(buff))
+	total := 0
+	for i, b := range buff {
+		sizes[i] = len(b)
+		total += len(b)
+	}
+	slices.Sort(sizes)
+
+	stats := SizeStats{
+		Count:       len(sizes),
+		Min:         sizes[0],
+		Max:         sizes[len(sizes)-1],
+		Mean:        float64(total) / float64(len(sizes)),
+		Percentiles: make(map[float64]int, len(c.sizePercentiles)),
+	}
+	for _, p := range c.sizePercentiles {
+		stats.Percentiles[p] = percentile(sizes, p)
+	}
+
+	c.sizeMu.Lock()
+	c.sizeStats = stats
+	c.sizeMu.Unlock()
+}
+
+// percentile returns p-th percentile of sorted values using nearest-rank method.
+func percentile(sorted []int, p float64) int {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = min(max(rank, 1), len(sorted))
+	return sorted[rank-1]
+}
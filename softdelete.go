@@ -0,0 +1,70 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// softDeleteField marks documents deleted using SoftDelete.
+const softDeleteField = "deleted_at"
+
+// SoftDelete marks document with the given ID as deleted by setting its deleted_at field
+// to the current time, ErrNotFound is returned if it doesn't exist.
+// Marked documents are skipped by Search and Count if WithSoftDeleteFilter is set.
+func (c *Client) SoftDelete(ctx context.Context, id string) error {
+	fields, err := json.Marshal(map[string]string{softDeleteField: time.Now().UTC().Format(time.RFC3339)})
+	if err != nil {
+		return err
+	}
+
+	return c.Merge(ctx, id, fields)
+}
+
+// HardDelete permanently deletes document with the given ID, ErrNotFound is returned if it doesn't exist.
+func (c *Client) HardDelete(ctx context.Context, id string) error {
+	return c.DeleteDocument(ctx, id)
+}
+
+// rawQuery is an already built query.
+type rawQuery json.RawMessage
+
+func (q rawQuery) Build() (json.RawMessage, error) {
+	return json.RawMessage(q), nil
+}
+
+// excludeSoftDeleted wraps query of the search request body so soft deleted documents don't match.
+func excludeSoftDeleted(body map[string]json.RawMessage) error {
+	var qb QueryBuilder
+	b := qb.Bool().MustNot(qb.Exists(softDeleteField))
+	if q, ok := body["query"]; ok {
+		b.Must(rawQuery(q))
+	}
+
+	q, err := b.Build()
+	if err != nil {
+		return err
+	}
+
+	body["query"] = q
+	return nil
+}
+
+// filterSoftDeleted excludes soft deleted documents from search request body if WithSoftDeleteFilter is set.
+func (c *Client) filterSoftDeleted(query json.RawMessage) (json.RawMessage, error) {
+	if !c.softDeleteFilter {
+		return query, nil
+	}
+
+	body := make(map[string]json.RawMessage)
+	if len(query) > 0 {
+		if err := json.Unmarshal(query, &body); err != nil {
+			return nil, err
+		}
+	}
+	if err := excludeSoftDeleted(body); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(body)
+}
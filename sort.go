@@ -0,0 +1,102 @@
+package zincmetric
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var (
+	// sortModes are allowed modes of sorting by multi-valued fields.
+	sortModes = map[string]bool{"min": true, "max": true, "sum": true, "avg": true}
+	// scriptSortTypes are allowed script sort value types.
+	scriptSortTypes = map[string]bool{"number": true, "string": true}
+)
+
+// SortBuilder builds search request sort clauses, e.g.
+//
+//	s := SortBuilder{}.ByField("timestamp", "desc", "", "_last")
+//
+// SortBuilder is immutable, every method returns an extended copy.
+type SortBuilder struct {
+	sorts []any
+	err   error
+}
+
+// add returns builder copy with the sort clause appended.
+func (s SortBuilder) add(sort any, err error) SortBuilder {
+	sorts := make([]any, len(s.sorts), len(s.sorts)+1)
+	copy(sorts, s.sorts)
+
+	if s.err != nil {
+		err = s.err
+	}
+
+	return SortBuilder{sorts: append(sorts, sort), err: err}
+}
+
+// ByField sorts by field in "asc" or "desc" order. Mode ("min", "max", "sum" or "avg") picks value
+// of multi-valued fields and missingPolicy ("_first", "_last" or a custom value) places documents
+// without the field, empty mode and missingPolicy mean ZincSearch defaults.
+func (s SortBuilder) ByField(field, order, mode, missingPolicy string) SortBuilder {
+	err := validateSortOrder(order)
+	if err == nil && mode != "" && !sortModes[mode] {
+		err = fmt.Errorf("invalid sort mode %q", mode)
+	}
+
+	opts := map[string]string{"order": order}
+	if mode != "" {
+		opts["mode"] = mode
+	}
+	if missingPolicy != "" {
+		opts["missing"] = missingPolicy
+	}
+
+	return s.add(map[string]any{field: opts}, err)
+}
+
+// ByScript sorts by value computed by script in "asc" or "desc" order,
+// scriptType is the computed value type, "number" or "string".
+func (s SortBuilder) ByScript(script json.RawMessage, order string, scriptType string) SortBuilder {
+	err := validateSortOrder(order)
+	if err == nil && !scriptSortTypes[scriptType] {
+		err = fmt.Errorf("invalid script sort type %q", scriptType)
+	}
+
+	opts := map[string]any{"type": scriptType, "script": script, "order": order}
+
+	return s.add(map[string]any{"_script": opts}, err)
+}
+
+// Build returns sort clauses as JSON array for search request "sort" key.
+func (s SortBuilder) Build() (json.RawMessage, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	if s.sorts == nil {
+		return json.RawMessage(`[]`), nil
+	}
+
+	return json.Marshal(s.sorts)
+}
+
+// SortBy sets search request sort to the built sort clauses.
+func SortBy(s SortBuilder) SearchOption {
+	return func(r *searchRequest) error {
+		b, err := s.Build()
+		if err != nil {
+			return err
+		}
+
+		r.body["sort"] = b
+		return nil
+	}
+}
+
+func validateSortOrder(order string) error {
+	if order != "asc" && order != "desc" {
+		return fmt.Errorf("invalid sort order %q, expected asc or desc", order)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package zincmetric
+
+import "fmt"
+
+// SpanQueryBuilder builds span queries, e.g.
+//
+//	var sb SpanQueryBuilder
+//	q := sb.SpanNot(sb.SpanTerm("message", "disk"), sb.SpanTerm("message", "virtual")).AsQuery()
+type SpanQueryBuilder struct{}
+
+// SpanTerm matches term positions of value in field.
+func (SpanQueryBuilder) SpanTerm(field, value string) SpanQuery {
+	return QueryBuilder{}.SpanTerm(field, value)
+}
+
+// SpanNear matches spans of clauses that are within slop positions of each other,
+// in the given order if inOrder is set.
+func (SpanQueryBuilder) SpanNear(clauses []SpanQuery, slop int, inOrder bool) SpanQuery {
+	return QueryBuilder{}.SpanNear(clauses, slop, inOrder)
+}
+
+// SpanFirst matches spans of match ending at most at end position of the field.
+func (SpanQueryBuilder) SpanFirst(match SpanQuery, end int) SpanQuery {
+	return QueryBuilder{}.SpanFirst(match, end)
+}
+
+// SpanOr matches spans of any of clauses.
+func (SpanQueryBuilder) SpanOr(clauses []SpanQuery) SpanQuery {
+	var err error
+	if len(clauses) == 0 {
+		err = fmt.Errorf("span or query requires at least one clause")
+	}
+
+	return spanClause{clause{name: "span_or", body: map[string]any{"clauses": spansJSON(clauses)}, err: err}}
+}
+
+// SpanNot matches spans of include not overlapping spans of exclude.
+func (SpanQueryBuilder) SpanNot(include, exclude SpanQuery) SpanQuery {
+	return spanPair("span_not", "include", include, "exclude", exclude)
+}
+
+// SpanContaining matches spans of big containing spans of little.
+func (SpanQueryBuilder) SpanContaining(little, big SpanQuery) SpanQuery {
+	return spanPair("span_containing", "little", little, "big", big)
+}
+
+// SpanWithin matches spans of little enclosed within spans of big.
+func (SpanQueryBuilder) SpanWithin(little, big SpanQuery) SpanQuery {
+	return spanPair("span_within", "little", little, "big", big)
+}
+
+// spanPair is a span query of two span clauses.
+func spanPair(name, firstKey string, first SpanQuery, secondKey string, second SpanQuery) SpanQuery {
+	var err error
+	if first == nil || second == nil {
+		err = fmt.Errorf("%s query requires both %s and %s clauses", name, firstKey, secondKey)
+	}
+
+	body := map[string]any{firstKey: queryJSON{first}, secondKey: queryJSON{second}}
+
+	return spanClause{clause{name: name, body: body, err: err}}
+}
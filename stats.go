@@ -0,0 +1,36 @@
+package zincmetric
+
+import "sync/atomic"
+
+// Stats holds client runtime statistics.
+type Stats struct {
+	// DocumentsSent is the number of documents successfully pushed to ZincSearch service.
+	DocumentsSent int64
+	// FlushErrors is the number of failed push requests.
+	FlushErrors int64
+	// BufferDepth is the number of documents waiting to be pushed.
+	BufferDepth int64
+	// DocumentsShed is the number of documents rejected by load shedding.
+	DocumentsShed int64
+	// CurrentBatchSize is the maximum number of documents pushed in a single request,
+	// 0 means the whole buffer is pushed at once.
+	CurrentBatchSize int
+}
+
+// clientStats holds counters updated by the pusher thread.
+type clientStats struct {
+	documentsSent atomic.Int64
+	flushErrors   atomic.Int64
+	documentsShed atomic.Int64
+}
+
+// Stats returns a snapshot of client runtime statistics.
+func (c *Client) Stats() Stats {
+	return Stats{
+		DocumentsSent:    c.stats.documentsSent.Load(),
+		FlushErrors:      c.stats.flushErrors.Load(),
+		BufferDepth:      c.bufferDepth.Load(),
+		DocumentsShed:    c.stats.documentsShed.Load(),
+		CurrentBatchSize: int(c.batchSize.Load()),
+	}
+}
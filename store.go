@@ -0,0 +1,75 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Store keeps JSON documents of type T in client's index by ID, using ZincSearch as a document database.
+type Store[T any] struct {
+	c *Client
+}
+
+// NewStore creates store keeping documents in client's index.
+func NewStore[T any](client *Client) *Store[T] {
+	return &Store[T]{c: client}
+}
+
+// Put creates or replaces document with the given ID.
+func (s *Store[T]) Put(ctx context.Context, id string, doc T) error {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+
+	return s.c.PutDocument(ctx, id, data)
+}
+
+// Get returns document with the given ID, ErrNotFound if it doesn't exist.
+func (s *Store[T]) Get(ctx context.Context, id string) (T, error) {
+	var doc T
+
+	data, err := s.c.GetDocument(ctx, id)
+	if err != nil {
+		return doc, err
+	}
+
+	err = json.Unmarshal(data, &doc)
+	return doc, err
+}
+
+// Delete deletes document with the given ID, ErrNotFound is returned if it doesn't exist.
+func (s *Store[T]) Delete(ctx context.Context, id string) error {
+	return s.c.DeleteDocument(ctx, id)
+}
+
+// List returns all documents matching filter (full search request body, e.g. {"query": {...}}),
+// nil filter returns all documents.
+func (s *Store[T]) List(ctx context.Context, filter json.RawMessage) ([]T, error) {
+	scroller := s.c.Scroll(filter, defaultScrollKeepAlive)
+	defer scroller.Close(context.WithoutCancel(ctx))
+
+	var docs []T
+	for {
+		res, err := scroller.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var page []T
+		if err := res.UnmarshalAll(&page); err != nil {
+			return nil, err
+		}
+		docs = append(docs, page...)
+	}
+}
+
+// Count returns the number of stored documents.
+func (s *Store[T]) Count(ctx context.Context) (int64, error) {
+	return s.c.Count(ctx, nil)
+}
@@ -0,0 +1,56 @@
+package zincmetric
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// currentIndex returns index the client currently writes to.
+func (c *Client) currentIndex() string {
+	c.indexMu.RLock()
+	defer c.indexMu.RUnlock()
+
+	return c.index
+}
+
+// documentURL returns single document endpoint of the given index.
+func (c *Client) documentURL(index string) (string, error) {
+	c.indexMu.RLock()
+	current, endpoint := c.index, c.singleDocumentURL
+	c.indexMu.RUnlock()
+
+	if index == current {
+		return endpoint, nil
+	}
+
+	return url.JoinPath(c.host, "api", index, "_doc")
+}
+
+// SwitchIndex makes client use newIndex for all following writes and requests,
+// after verifying the index is accessible. Documents written before the switch
+// are still pushed to the old index.
+func (c *Client) SwitchIndex(ctx context.Context, newIndex string) error {
+	newIndex = c.normalizeIndex(newIndex)
+
+	endpoint, err := c.endpoint("api", "index", newIndex)
+	if err != nil {
+		return err
+	}
+
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, nil); err != nil {
+		return err
+	}
+
+	documentURL, err := url.JoinPath(c.host, "api", newIndex, "_doc")
+	if err != nil {
+		return err
+	}
+
+	c.indexMu.Lock()
+	c.index = newIndex
+	c.singleDocumentURL = documentURL
+	c.indexMu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// taskPollInterval is how often WaitForTask checks task progress.
+const taskPollInterval = time.Second
+
+// OperationResult identifies a long-running operation, its progress can be polled using GetTask.
+type OperationResult struct {
+	TaskID string
+}
+
+// Task describes progress of a long-running operation.
+type Task struct {
+	Completed bool `json:"completed"`
+	// Task holds operation status details.
+	Task json.RawMessage `json:"task,omitempty"`
+	// Response holds operation result once completed.
+	Response json.RawMessage `json:"response,omitempty"`
+	// Error holds operation error, if it failed.
+	Error json.RawMessage `json:"error,omitempty"`
+}
+
+// taskResponse is the response of an operation started without waiting for completion.
+type taskResponse struct {
+	Task string `json:"task"`
+}
+
+// Shrink starts shrinking client's index into targetIndex.
+func (c *Client) Shrink(ctx context.Context, targetIndex string) (*OperationResult, error) {
+	endpoint, err := c.endpoint("es", c.currentIndex(), "_shrink", c.normalizeIndex(targetIndex))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.startTask(ctx, endpoint, nil)
+}
+
+// ForceMerge starts merging client's index segments down to at most maxSegments.
+func (c *Client) ForceMerge(ctx context.Context, maxSegments int) (*OperationResult, error) {
+	endpoint, err := c.endpoint("es", c.currentIndex(), "_forcemerge")
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	if maxSegments > 0 {
+		params.Set("max_num_segments", strconv.Itoa(maxSegments))
+	}
+
+	return c.startTask(ctx, endpoint, params)
+}
+
+// Compact starts merging client's index segments down to at most maxSegmentsPerShard
+// (0 lets the server decide) without waiting for the merge to complete, see ForceMerge.
+func (c *Client) Compact(ctx context.Context, maxSegmentsPerShard int) error {
+	_, err := c.ForceMerge(ctx, maxSegmentsPerShard)
+	return err
+}
+
+// CompactAndWait merges client's index segments like Compact, waiting for the merge to complete.
+func (c *Client) CompactAndWait(ctx context.Context, maxSegmentsPerShard int) error {
+	op, err := c.ForceMerge(ctx, maxSegmentsPerShard)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.WaitForTask(ctx, op.TaskID)
+	return err
+}
+
+// WaitForTask polls progress of the operation with the given task ID until it completes or ctx is
+// done. Error is returned if the operation failed. Empty task ID means the operation already completed.
+func (c *Client) WaitForTask(ctx context.Context, taskID string) (*Task, error) {
+	if taskID == "" {
+		return &Task{Completed: true}, nil
+	}
+
+	tick := time.NewTicker(taskPollInterval)
+	defer tick.Stop()
+
+	for {
+		task, err := c.GetTask(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		if len(task.Error) > 0 && string(task.Error) != "null" {
+			return task, fmt.Errorf("task %s failed: %s", taskID, task.Error)
+		}
+		if task.Completed {
+			return task, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-tick.C:
+		}
+	}
+}
+
+// GetTask returns progress of the operation with the given task ID.
+func (c *Client) GetTask(ctx context.Context, taskID string) (*Task, error) {
+	endpoint, err := c.endpoint("es", "_tasks", taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var task Task
+	if err := c.doJSON(ctx, http.MethodGet, endpoint, nil, &task); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// startTask starts the operation without waiting for it to complete.
+func (c *Client) startTask(ctx context.Context, endpoint string, params url.Values) (*OperationResult, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("wait_for_completion", "false")
+
+	var resp taskResponse
+	if err := c.doJSON(ctx, http.MethodPost, endpoint+"?"+params.Encode(), nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &OperationResult{TaskID: resp.Task}, nil
+}
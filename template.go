@@ -0,0 +1,74 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// templateRequest references a stored search template rendered with params.
+type templateRequest struct {
+	ID     string         `json:"id"`
+	Params map[string]any `json:"params,omitempty"`
+}
+
+// RegisterTemplate stores mustache search template with the given ID, e.g.
+// {"query": {"match": {"{{field}}": "{{value}}"}}}.
+func (c *Client) RegisterTemplate(ctx context.Context, id string, template json.RawMessage) error {
+	endpoint, err := c.endpoint("es", "_scripts", id)
+	if err != nil {
+		return err
+	}
+
+	script := map[string]map[string]any{"script": {"lang": "mustache", "source": template}}
+
+	return c.doJSON(ctx, http.MethodPut, endpoint, script, nil)
+}
+
+// DeleteTemplate deletes search template with the given ID.
+func (c *Client) DeleteTemplate(ctx context.Context, id string) error {
+	endpoint, err := c.endpoint("es", "_scripts", id)
+	if err != nil {
+		return err
+	}
+
+	err = c.doJSON(ctx, http.MethodDelete, endpoint, nil, nil)
+	if isStatus(err, http.StatusNotFound) {
+		return ErrNotFound
+	}
+
+	return err
+}
+
+// SearchWithTemplate runs search template with the given ID rendered with params against client's index.
+func (c *Client) SearchWithTemplate(ctx context.Context, templateID string, params map[string]any) (*SearchResult, error) {
+	endpoint, err := c.endpoint("es", c.currentIndex(), "_search", "template")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(templateRequest{ID: templateID, Params: params})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.search(ctx, c.readEndpoint(endpoint), body)
+}
+
+// RenderTemplate returns search request body search template with the given ID renders to with params,
+// without running the search.
+func (c *Client) RenderTemplate(ctx context.Context, templateID string, params map[string]any) (json.RawMessage, error) {
+	endpoint, err := c.endpoint("es", "_render", "template")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		TemplateOutput json.RawMessage `json:"template_output"`
+	}
+	if err := c.doJSON(ctx, http.MethodPost, endpoint, templateRequest{ID: templateID, Params: params}, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.TemplateOutput, nil
+}
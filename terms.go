@@ -0,0 +1,77 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// TermFreq is a term together with the number of documents containing it.
+type TermFreq struct {
+	Term     string
+	DocCount int64
+}
+
+// termsAggregation is the terms aggregation result.
+type termsAggregation struct {
+	Buckets []struct {
+		Key      json.RawMessage `json:"key"`
+		DocCount int64           `json:"doc_count"`
+	} `json:"buckets"`
+}
+
+// TermFrequency returns the number of documents containing term in field.
+func (c *Client) TermFrequency(ctx context.Context, field, term string) (int64, error) {
+	query, err := json.Marshal(map[string]any{
+		"query": map[string]any{"term": map[string]string{field: term}},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return c.Count(ctx, query)
+}
+
+// TopTerms returns up to size most frequent terms of field, sorted by document frequency.
+func (c *Client) TopTerms(ctx context.Context, field string, size int) ([]TermFreq, error) {
+	query, err := json.Marshal(map[string]any{
+		"size": 0,
+		"aggs": map[string]any{
+			"top_terms": map[string]any{"terms": map[string]any{"field": field, "size": size}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var aggs struct {
+		TopTerms termsAggregation `json:"top_terms"`
+	}
+	if len(res.Aggregations) > 0 {
+		if err := json.Unmarshal(res.Aggregations, &aggs); err != nil {
+			return nil, err
+		}
+	}
+
+	terms := make([]TermFreq, len(aggs.TopTerms.Buckets))
+	for i, b := range aggs.TopTerms.Buckets {
+		terms[i] = TermFreq{Term: bucketKey(b.Key), DocCount: b.DocCount}
+	}
+
+	return terms, nil
+}
+
+// bucketKey returns aggregation bucket key as string, numeric keys are kept as is.
+func bucketKey(key json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(key, &s); err == nil {
+		return s
+	}
+
+	return strings.TrimSpace(string(key))
+}
@@ -0,0 +1,92 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var (
+	// timeSeriesAggs are metric aggregations allowed in time series queries.
+	timeSeriesAggs = map[string]bool{"avg": true, "sum": true, "max": true, "min": true}
+	// calendarIntervalRe matches calendar aware date histogram intervals.
+	calendarIntervalRe = regexp.MustCompile(`^1?[wMqy]$`)
+)
+
+// TimeSeriesResult is a metric aggregated over time buckets.
+type TimeSeriesResult struct {
+	Buckets []TimeBucket
+}
+
+// TimeBucket is a metric value of a single time interval.
+type TimeBucket struct {
+	Timestamp time.Time
+	// Value is the aggregated metric, 0 if the bucket has no documents.
+	Value    float64
+	DocCount int64
+}
+
+// timeSeriesAggregation is the date histogram aggregation result.
+type timeSeriesAggregation struct {
+	Buckets []struct {
+		Key      int64 `json:"key"`
+		DocCount int64 `json:"doc_count"`
+		Value    struct {
+			Value *float64 `json:"value"`
+		} `json:"value"`
+	} `json:"buckets"`
+}
+
+// TimeSeriesQuery aggregates metricField of documents with field date between from and to into
+// time buckets of interval (e.g. "5m", "1h", or calendar "1M"), agg is one of "avg", "sum", "max" or "min".
+func (c *Client) TimeSeriesQuery(ctx context.Context, field, metricField string, from, to time.Time, interval string, agg string) (*TimeSeriesResult, error) {
+	if !timeSeriesAggs[agg] {
+		return nil, fmt.Errorf("invalid time series aggregation %q", agg)
+	}
+
+	intervalKey := "fixed_interval"
+	if calendarIntervalRe.MatchString(interval) {
+		intervalKey = "calendar_interval"
+	}
+
+	query, err := json.Marshal(map[string]any{
+		"size": 0,
+		"query": map[string]any{"range": map[string]any{field: map[string]string{
+			"gte": from.UTC().Format(time.RFC3339Nano),
+			"lte": to.UTC().Format(time.RFC3339Nano),
+		}}},
+		"aggs": map[string]any{"series": map[string]any{
+			"date_histogram": map[string]string{"field": field, intervalKey: interval},
+			"aggs":           map[string]any{"value": map[string]any{agg: map[string]string{"field": metricField}}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var aggs struct {
+		Series timeSeriesAggregation `json:"series"`
+	}
+	if len(res.Aggregations) > 0 {
+		if err := json.Unmarshal(res.Aggregations, &aggs); err != nil {
+			return nil, err
+		}
+	}
+
+	buckets := make([]TimeBucket, len(aggs.Series.Buckets))
+	for i, b := range aggs.Series.Buckets {
+		buckets[i] = TimeBucket{Timestamp: time.UnixMilli(b.Key).UTC(), DocCount: b.DocCount}
+		if b.Value.Value != nil {
+			buckets[i].Value = *b.Value.Value
+		}
+	}
+
+	return &TimeSeriesResult{Buckets: buckets}, nil
+}
@@ -0,0 +1,50 @@
+package zincmetric
+
+import "encoding/json"
+
+// TopHits returns size best matching hits of each parent bucket (e.g. of Terms aggregation), sorted
+// by sort clauses (e.g. {"@timestamp": "desc"}) instead of score if set. Only source fields are
+// returned if set. Results can be read using SearchResult.TopHitsGroups, top hits of the whole
+// query decode into InnerHits.
+func (AggregationBuilder) TopHits(name string, size int, sort []json.RawMessage, source []string) Aggregation {
+	body := map[string]any{"size": size}
+	if len(sort) > 0 {
+		body["sort"] = sort
+	}
+	if len(source) > 0 {
+		body["_source"] = source
+	}
+
+	return aggregation{name: name, typ: "top_hits", body: body}
+}
+
+// TopHitsAggResult holds top hits of every bucket of bucket aggregation.
+type TopHitsAggResult struct {
+	Groups []HitGroup
+}
+
+// HitGroup is a bucket together with its top hits.
+type HitGroup struct {
+	Key      string
+	DocCount int64
+	Hits     []SearchHit
+}
+
+// TopHitsGroups returns hits of topHits sub aggregation of every bucket of the named aggregation.
+func (r *SearchResult) TopHitsGroups(name, topHits string) (*TopHitsAggResult, error) {
+	var buckets BucketsResult
+	if err := r.DecodeAggregation(name, &buckets); err != nil {
+		return nil, err
+	}
+
+	groups := make([]HitGroup, len(buckets.Buckets))
+	for i, b := range buckets.Buckets {
+		var hits InnerHits
+		if err := b.Aggregations.Decode(topHits, &hits); err != nil {
+			return nil, err
+		}
+		groups[i] = HitGroup{Key: b.KeyString(), DocCount: b.DocCount, Hits: hits.Hits}
+	}
+
+	return &TopHitsAggResult{Groups: groups}, nil
+}
@@ -0,0 +1,61 @@
+package zincmetric
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// Transaction collects writes of Client.Transact.
+type Transaction struct {
+	ctx  context.Context
+	c    *Client
+	docs [][]byte
+}
+
+// Write buffers the document until the transaction commits. Data is expected to be in JSON format.
+func (tx *Transaction) Write(data []byte) error {
+	doc, err := applyTransforms(bytes.Clone(tx.c.enrich(tx.ctx, data)), tx.c.transforms)
+	if err != nil {
+		return err
+	}
+	if doc, err = tx.c.trackCreated(doc); err != nil {
+		return err
+	}
+	if doc, err = tx.c.assignID(doc); err != nil {
+		return err
+	}
+
+	tx.docs = append(tx.docs, doc)
+	return nil
+}
+
+// Search runs query against client's index, documents written in the transaction aren't visible yet.
+func (tx *Transaction) Search(query json.RawMessage, opts ...SearchOption) (*SearchResult, error) {
+	return tx.c.Search(tx.ctx, query, opts...)
+}
+
+// Transact runs fn, pushing documents it writes in a single bulk request once it returns nil and
+// refreshing the index, so they're searchable once Transact returns. Nothing is written if fn
+// returns an error. ZincSearch has no transactions, so documents of a bulk that failed half way
+// may be partially written.
+func (c *Client) Transact(ctx context.Context, fn func(tx *Transaction) error) error {
+	tx := &Transaction{ctx: ctx, c: c}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if len(tx.docs) == 0 {
+		return nil
+	}
+
+	for _, doc := range tx.docs {
+		c.audit(doc)
+	}
+	pushed, err := c.flushBuffer(c.currentIndex(), tx.docs)
+	c.stats.documentsSent.Add(int64(pushed))
+	if err != nil {
+		return err
+	}
+
+	return c.Refresh(ctx)
+}
@@ -0,0 +1,46 @@
+package zincmetric
+
+import (
+	"io"
+)
+
+// TransformFunc modifies a document before it's written.
+type TransformFunc func(doc []byte) ([]byte, error)
+
+// TransformWriter applies transformations to documents before writing them to underlying writer.
+type TransformWriter struct {
+	w          io.Writer
+	transforms []TransformFunc
+}
+
+// NewTransformWriter creates TransformWriter applying transforms in the given order.
+func NewTransformWriter(w io.Writer, transforms ...TransformFunc) *TransformWriter {
+	return &TransformWriter{w: w, transforms: transforms}
+}
+
+// Write transforms the document and writes it to underlying writer.
+func (t *TransformWriter) Write(data []byte) (int, error) {
+	doc, err := applyTransforms(data, t.transforms)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := t.w.Write(doc); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// applyTransforms runs document through all transforms.
+func applyTransforms(doc []byte, transforms []TransformFunc) ([]byte, error) {
+	var err error
+	for _, fn := range transforms {
+		doc, err = fn(doc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,142 @@
+package zincmetric
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// QueryError is a mistake found in query DSL by ValidateQuery.
+type QueryError struct {
+	// Path locates the mistake, e.g. "query.bool.must[0].range".
+	Path    string
+	Message string
+}
+
+func (e QueryError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// boolClauses are bool query keys holding nested queries.
+var boolClauses = []string{"must", "should", "filter", "must_not"}
+
+// ValidateQuery checks query for common mistakes: bool query without clauses, range without bounds,
+// term with null value, match without query and nested query without path. Query can be either
+// a full search request body or a query clause. Returns an empty slice for valid queries.
+func ValidateQuery(query json.RawMessage) []QueryError {
+	errs := []QueryError{}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(query, &body); err != nil {
+		return append(errs, QueryError{Message: err.Error()})
+	}
+
+	path := ""
+	q, ok := body["query"]
+	if !ok && searchRequestKeys(body) {
+		return errs // Request body matching all documents.
+	}
+	if ok {
+		path = "query"
+		body = make(map[string]json.RawMessage)
+		if err := json.Unmarshal(q, &body); err != nil {
+			return append(errs, QueryError{Path: path, Message: err.Error()})
+		}
+	}
+
+	return validateClause(errs, path, body)
+}
+
+// validateClause validates query clause like {"term": {...}} at path.
+func validateClause(errs []QueryError, path string, q map[string]json.RawMessage) []QueryError {
+	for name, raw := range q {
+		p := name
+		if path != "" {
+			p = path + "." + name
+		}
+
+		var body map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &body); err != nil {
+			errs = append(errs, QueryError{Path: p, Message: "expected object"})
+			continue
+		}
+
+		switch name {
+		case "bool":
+			errs = validateBool(errs, p, body)
+		case "range":
+			for field, bounds := range body {
+				var b map[string]json.RawMessage
+				_ = json.Unmarshal(bounds, &b)
+				if b["gt"] == nil && b["gte"] == nil && b["lt"] == nil && b["lte"] == nil {
+					errs = append(errs, QueryError{Path: p + "." + field, Message: "range has no bounds"})
+				}
+			}
+		case "term":
+			for field, v := range body {
+				var value struct {
+					Value json.RawMessage `json:"value"`
+				}
+				isObject := json.Unmarshal(v, &value) == nil
+				if string(v) == "null" || (isObject && (value.Value == nil || string(value.Value) == "null")) {
+					errs = append(errs, QueryError{Path: p + "." + field, Message: "term value is null"})
+				}
+			}
+		case "match":
+			for field, v := range body {
+				var m map[string]json.RawMessage
+				if json.Unmarshal(v, &m) == nil && m["query"] == nil {
+					errs = append(errs, QueryError{Path: p + "." + field, Message: "match has no query"})
+				}
+			}
+		case "nested":
+			var nestedPath string
+			if json.Unmarshal(body["path"], &nestedPath) != nil || nestedPath == "" {
+				errs = append(errs, QueryError{Path: p, Message: "nested query has no path"})
+			}
+			errs = validateNested(errs, p+".query", body["query"])
+		}
+	}
+
+	return errs
+}
+
+// validateBool validates bool query body at path.
+func validateBool(errs []QueryError, path string, body map[string]json.RawMessage) []QueryError {
+	empty := true
+	for _, key := range boolClauses {
+		raw, ok := body[key]
+		if !ok {
+			continue
+		}
+		empty = false
+
+		var clauses []json.RawMessage
+		if json.Unmarshal(raw, &clauses) != nil {
+			errs = validateNested(errs, path+"."+key, raw) // Single clause instead of array.
+			continue
+		}
+		for i, c := range clauses {
+			errs = validateNested(errs, fmt.Sprintf("%s.%s[%d]", path, key, i), c)
+		}
+	}
+
+	if empty {
+		errs = append(errs, QueryError{Path: path, Message: "bool query has no must, should, filter or must_not clauses"})
+	}
+
+	return errs
+}
+
+// validateNested validates raw nested query clause at path.
+func validateNested(errs []QueryError, path string, raw json.RawMessage) []QueryError {
+	if raw == nil {
+		return errs
+	}
+
+	var q map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &q); err != nil {
+		return append(errs, QueryError{Path: path, Message: "expected object"})
+	}
+
+	return validateClause(errs, path, q)
+}
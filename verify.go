@@ -0,0 +1,40 @@
+package zincmetric
+
+import "context"
+
+// verificationCount returns document count of index before flush, -1 if it couldn't be counted.
+func (c *Client) verificationCount(index string) int64 {
+	n, err := c.count(context.Background(), index, nil)
+	if err != nil {
+		c.logger.Error("failed to count index documents", logKeyIndex, index, logKeyError, err)
+		return -1
+	}
+
+	return n
+}
+
+// verifyFlush refreshes index and checks it grew by the number of flushed documents,
+// passing them to WithOnError callback with ErrVerificationFailed if it didn't.
+// Concurrent writers and deletes make the check best-effort.
+func (c *Client) verifyFlush(index string, before int64, docs [][]byte) {
+	if before < 0 {
+		return
+	}
+
+	ctx := context.Background()
+	if err := c.refresh(ctx, index); err != nil {
+		c.logger.Error("failed to refresh index", logKeyIndex, index, logKeyError, err)
+		return
+	}
+
+	after, err := c.count(ctx, index, nil)
+	if err != nil {
+		c.logger.Error("failed to count index documents", logKeyIndex, index, logKeyError, err)
+		return
+	}
+
+	if after-before+c.verificationTolerance < int64(len(docs)) {
+		c.logger.Warn("flushed documents missing from index", logKeyIndex, index, logKeyBatchSize, len(docs))
+		c.notifyError(docs, ErrVerificationFailed)
+	}
+}
@@ -0,0 +1,181 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// createdAtField and updatedAtField are set on documents if WithVersionTracking is set.
+	createdAtField = "_created_at"
+	updatedAtField = "_updated_at"
+
+	// historyIDField holds ID of the document the version in history index belongs to.
+	historyIDField = "_doc_id"
+
+	// maxHistoryVersions limits number of versions returned by GetHistory.
+	maxHistoryVersions = 1000
+)
+
+// historyIndex returns name of the index holding document versions of index.
+func historyIndex(index string) string {
+	return index + "_history"
+}
+
+// trackCreated sets _created_at and _updated_at fields of buffered document not having them if
+// WithVersionTracking is set, so CDC monitor sees the document created. It's called after
+// deduplication, so the time doesn't make identical documents differ.
+func (c *Client) trackCreated(doc []byte) ([]byte, error) {
+	if !c.versionTracking {
+		return doc, nil
+	}
+
+	now := time.Now()
+	doc, err := setTimeField(doc, createdAtField, now, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return setTimeField(doc, updatedAtField, now, false)
+}
+
+// keepCreated sets _created_at field of document replacing the one with the given ID to the value
+// of the stored document, or to the current time if there's none, when WithVersionTracking is set.
+func (c *Client) keepCreated(ctx context.Context, id string, data []byte) ([]byte, error) {
+	if !c.versionTracking {
+		return data, nil
+	}
+
+	current, err := c.getDocument(ctx, id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("get document creation time: %w", err)
+	}
+
+	if current != nil {
+		var stored map[string]json.RawMessage
+		if err := json.Unmarshal(current.Source, &stored); err != nil {
+			return nil, err
+		}
+		if createdAt, ok := stored[createdAtField]; ok {
+			return setField(data, createdAtField, createdAt, true)
+		}
+	}
+
+	return setTimeField(data, createdAtField, time.Now(), false)
+}
+
+// setTimeField sets field of JSON object document to t, existing value is kept unless overwrite is set.
+func setTimeField(doc []byte, field string, t time.Time, overwrite bool) ([]byte, error) {
+	value, _ := json.Marshal(t.UTC().Format(time.RFC3339))
+	return setField(doc, field, value, overwrite)
+}
+
+// setField sets field of JSON object document to value, existing value is kept unless overwrite is set.
+func setField(doc []byte, field string, value json.RawMessage, overwrite bool) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(doc, &fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = make(map[string]json.RawMessage, 1)
+	}
+	if _, ok := fields[field]; ok && !overwrite {
+		return doc, nil
+	}
+
+	fields[field] = value
+
+	return json.Marshal(fields)
+}
+
+// trackUpdate sets _updated_at field of document being updated.
+func (c *Client) trackUpdate(data []byte) ([]byte, error) {
+	if !c.versionTracking {
+		return data, nil
+	}
+
+	return setTimeField(data, updatedAtField, time.Now(), true)
+}
+
+// recordVersion stores written document version in history index.
+func (c *Client) recordVersion(id string, data []byte) error {
+	if !c.versionTracking {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if fields == nil {
+		fields = make(map[string]json.RawMessage, 1)
+	}
+	fields[historyIDField], _ = json.Marshal(id)
+
+	version, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+
+	// Versions are written without WithIngestPipeline pipeline, which is meant for client's documents.
+	endpoint, err := c.endpoint("api", historyIndex(c.currentIndex()), "_doc")
+	if err != nil {
+		return err
+	}
+	if err := c.doJSON(context.Background(), http.MethodPost, endpoint, json.RawMessage(version), nil); err != nil {
+		return fmt.Errorf("record document version: %w", err)
+	}
+
+	return nil
+}
+
+// GetHistory returns versions of the document with the given ID written by PutDocument,
+// Update, UpdateWithVersion and Merge, oldest first. Versions are only recorded if
+// WithVersionTracking is set, they're stored in a separate index named <index>_history.
+func (c *Client) GetHistory(ctx context.Context, id string) ([]json.RawMessage, error) {
+	endpoint, err := c.endpoint("es", historyIndex(c.currentIndex()), "_search")
+	if err != nil {
+		return nil, err
+	}
+
+	var qb QueryBuilder
+	query, err := qb.Term(historyIDField, id).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"query": query,
+		"sort":  []map[string]string{{updatedAtField: "asc"}},
+		"size":  maxHistoryVersions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.search(ctx, c.readEndpoint(endpoint), body)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]json.RawMessage, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(hit.Source, &fields); err != nil {
+			return nil, err
+		}
+		delete(fields, historyIDField)
+
+		version, err := json.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,28 @@
+package zincmetric
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// WarmIndex runs warmup queries (full request bodies) against client's index
+// discarding the results, so the first real searches don't hit cold caches.
+func (c *Client) WarmIndex(ctx context.Context, warmupQueries []json.RawMessage) error {
+	return c.warmIndex(ctx, c.currentIndex(), warmupQueries)
+}
+
+// warmIndex runs warmup queries against the given index, bypassing query cache.
+func (c *Client) warmIndex(ctx context.Context, index string, queries []json.RawMessage) error {
+	endpoint, err := c.endpoint("es", index, "_search")
+	if err != nil {
+		return err
+	}
+
+	for _, q := range queries {
+		if _, err := c.search(ctx, endpoint, q); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package zincmetric
+
+import (
+	"context"
+	"time"
+)
+
+// IndexEvent describes index change between two WatchIndex samples.
+type IndexEvent struct {
+	DocCountDelta int64
+	StorageDelta  int64
+}
+
+// WatchIndex samples IndexStats every interval and sends an event whenever document
+// count or storage size changed since the previous sample. Failed samples are logged
+// and skipped. Returned channel is closed once ctx is cancelled.
+func (c *Client) WatchIndex(ctx context.Context, interval time.Duration) <-chan IndexEvent {
+	events := make(chan IndexEvent)
+
+	go func() {
+		defer close(events)
+
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
+
+		var prev *IndexStats
+		for {
+			stats, err := c.IndexStats(ctx)
+			if err != nil && ctx.Err() == nil {
+				c.logger.Error("failed to sample index stats", logKeyIndex, c.currentIndex(), logKeyError, err)
+			}
+
+			if err == nil && prev != nil {
+				ev := IndexEvent{
+					DocCountDelta: stats.DocCount - prev.DocCount,
+					StorageDelta:  stats.StorageSize - prev.StorageSize,
+				}
+				if ev != (IndexEvent{}) {
+					select {
+					case events <- ev:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			if err == nil {
+				prev = stats
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick.C:
+			}
+		}
+	}()
+
+	return events
+}
@@ -0,0 +1,69 @@
+package zincmetric
+
+import (
+	"bytes"
+	"sync"
+)
+
+// WriteBuffer accumulates documents and writes them to the client in batches of
+// the configured size, letting producer decide which documents are flushed together.
+type WriteBuffer struct {
+	client *Client
+	size   int
+
+	mu   sync.Mutex
+	docs [][]byte
+}
+
+// NewWriteBuffer creates WriteBuffer passing documents to client in batches of size.
+func NewWriteBuffer(client *Client, size int) *WriteBuffer {
+	size = max(size, 1)
+	return &WriteBuffer{
+		client: client,
+		size:   size,
+		docs:   make([][]byte, 0, size),
+	}
+}
+
+// Write adds document to the buffer, writing the whole batch to the client once it's full.
+func (w *WriteBuffer) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.docs = append(w.docs, bytes.Clone(data))
+	if len(w.docs) < w.size {
+		return len(data), nil
+	}
+
+	if err := w.flush(); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+// Flush writes buffered documents to the client, even if the batch isn't full.
+func (w *WriteBuffer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.flush()
+}
+
+// Close flushes buffered documents, closing the client is left to the caller.
+func (w *WriteBuffer) Close() error {
+	return w.Flush()
+}
+
+func (w *WriteBuffer) flush() error {
+	if len(w.docs) == 0 {
+		return nil
+	}
+
+	if err := w.client.WriteBatch(w.docs); err != nil {
+		return err
+	}
+
+	w.docs = make([][]byte, 0, w.size)
+	return nil
+}